@@ -0,0 +1,403 @@
+package emailvalidator
+
+import (
+	"fmt"
+)
+
+// ABNFEngine is a SyntaxValidator implemented as a direct recursive-descent transcription of the RFC 5322 addr-spec
+// grammar, section 3.4.1, including CFWS and nested comments.  It favors fidelity over speed, and is intended both as
+// a correctness oracle for the default engine and for users who want the grammar and nothing else.
+//
+// Only the grammar is enforced: RFC 5321 length limits and the semantics of domain literal content are not checked.
+// It populates the Input, Local, Mailbox, Domain, LiteralDomain, Quoted, Comment, TrailingComment, and Stripped fields
+// of the Result, and ignores all options other than those used to localize messages.
+type ABNFEngine struct {
+	// AllowObsolete, if true, additionally accepts the obs-local-part and obs-domain forms of section 4.4, e.g.
+	// "john"."doe"@example.com
+	AllowObsolete bool
+}
+
+func (e ABNFEngine) Parse(email string, opts ParseOptions) (Result, error) {
+	p := &abnfParser{s: email}
+
+	res := Result{Input: email}
+
+	var ok bool
+	if res.Local, res.Quoted, ok = p.localPart(e.AllowObsolete); !ok {
+		return p.reject(res, "local-part", SegmentLocal, &opts)
+	}
+	p.pos++ // "@"
+
+	p.inDomain = true
+	if res.Domain, res.LiteralDomain, ok = p.domain(e.AllowObsolete); !ok {
+		return p.reject(res, "domain", SegmentDomain, &opts)
+	}
+
+	for _, c := range p.comments {
+		if c.start >= p.domainEnd && res.TrailingComment == "" {
+			res.TrailingComment = c.text
+		} else if c.start < p.domainEnd && res.Comment == "" {
+			res.Comment = c.text
+		}
+	}
+
+	res.Mailbox = res.Local
+	res.Stripped = fmt.Sprintf("%s@%s", minimalLocal(res.Local, res.Quoted), res.Domain)
+	res.Flags = flagsOf(&res)
+	return res, nil
+}
+
+type abnfComment struct {
+	text  string
+	start int
+}
+
+type abnfParser struct {
+	s        string
+	pos      int
+	furthest int
+	comments []abnfComment
+
+	// inDomain is true once the local-part has been parsed, and domainEnd is the offset following the last domain
+	// text seen, used to identify trailing comments
+	inDomain  bool
+	domainEnd int
+}
+
+type abnfState struct {
+	pos      int
+	comments int
+}
+
+func (p *abnfParser) save() abnfState {
+	return abnfState{pos: p.pos, comments: len(p.comments)}
+}
+
+func (p *abnfParser) restore(st abnfState) {
+	if p.pos > p.furthest {
+		p.furthest = p.pos
+	}
+	p.pos = st.pos
+	p.comments = p.comments[:st.comments]
+}
+
+func (p *abnfParser) peek() byte {
+	if p.pos < len(p.s) {
+		return p.s[p.pos]
+	}
+	return 0
+}
+
+// reject builds the error for input that could not be matched, positioned at the furthest offset reached
+func (p *abnfParser) reject(res Result, production string, segment Segment, opts *ParseOptions) (Result, error) {
+	if p.pos > p.furthest {
+		p.furthest = p.pos
+	}
+	chr := ""
+	if p.furthest < len(p.s) {
+		chr = string(p.s[p.furthest])
+	}
+	err := fmt.Errorf("%w: invalid %s at position %d", ErrEngineRejected, production, p.furthest)
+	res.Err = localize(newParseError(err, p.furthest, chr, segment), opts)
+	return res, res.Err
+}
+
+func isWSP(c byte) bool {
+	return c == ' ' || c == '\t'
+}
+
+// ctext = %d33-39 / %d42-91 / %d93-126
+func isCtext(c byte) bool {
+	return (33 <= c && c <= 39) || (42 <= c && c <= 91) || (93 <= c && c <= 126)
+}
+
+// qtext = %d33 / %d35-91 / %d93-126
+func isQtext(c byte) bool {
+	return c == 33 || (35 <= c && c <= 91) || (93 <= c && c <= 126)
+}
+
+// dtext = %d33-90 / %d94-126
+func isABNFDtext(c byte) bool {
+	return (33 <= c && c <= 90) || (94 <= c && c <= 126)
+}
+
+func (p *abnfParser) markDomainText() {
+	if p.inDomain {
+		p.domainEnd = p.pos
+	}
+}
+
+// fws = ([*WSP CRLF] 1*WSP)
+func (p *abnfParser) fws() bool {
+	j := p.pos
+	for j < len(p.s) && isWSP(p.s[j]) {
+		j++
+	}
+	if j+2 < len(p.s) && p.s[j] == '\r' && p.s[j+1] == '\n' && isWSP(p.s[j+2]) {
+		for j += 2; j < len(p.s) && isWSP(p.s[j]); j++ {
+		}
+	}
+	if j == p.pos {
+		return false
+	}
+	p.pos = j
+	return true
+}
+
+// quoted-pair = "\" (VCHAR / WSP)
+func (p *abnfParser) quotedPair() bool {
+	if p.peek() != '\\' || p.pos+1 >= len(p.s) {
+		return false
+	}
+	if c := p.s[p.pos+1]; (33 <= c && c <= 126) || isWSP(c) {
+		p.pos += 2
+		return true
+	}
+	return false
+}
+
+// comment = "(" *([FWS] ccontent) [FWS] ")", ccontent = ctext / quoted-pair / comment
+func (p *abnfParser) comment(depth int) bool {
+	if p.peek() != '(' {
+		return false
+	}
+	st := p.save()
+	p.pos++
+	for {
+		p.fws()
+		switch c := p.peek(); {
+		case c == ')':
+			p.pos++
+			if depth == 0 {
+				p.comments = append(p.comments[:st.comments], abnfComment{
+					text:  p.s[st.pos+1 : p.pos-1],
+					start: st.pos,
+				})
+			}
+			return true
+		case c == '(':
+			if !p.comment(depth + 1) {
+				p.restore(st)
+				return false
+			}
+		case c == '\\':
+			if !p.quotedPair() {
+				p.restore(st)
+				return false
+			}
+		case isCtext(c):
+			p.pos++
+		default:
+			p.restore(st)
+			return false
+		}
+	}
+}
+
+// cfws = (1*([FWS] comment) [FWS]) / FWS
+func (p *abnfParser) cfws() bool {
+	matched := false
+	for {
+		if p.fws() {
+			matched = true
+		}
+		if !p.comment(0) {
+			return matched
+		}
+		matched = true
+	}
+}
+
+// atext-run = 1*atext
+func (p *abnfParser) atextRun() bool {
+	start := p.pos
+	for p.pos < len(p.s) && isAtext(p.s[p.pos]) {
+		p.pos++
+	}
+	return p.pos > start
+}
+
+// dot-atom-text = 1*atext *("." 1*atext)
+func (p *abnfParser) dotAtomText() (string, bool) {
+	start := p.pos
+	if !p.atextRun() {
+		return "", false
+	}
+	for p.peek() == '.' {
+		st := p.save()
+		p.pos++
+		if !p.atextRun() {
+			p.restore(st)
+			break
+		}
+	}
+	return p.s[start:p.pos], true
+}
+
+// dot-atom = [CFWS] dot-atom-text [CFWS]
+func (p *abnfParser) dotAtom() (string, bool) {
+	st := p.save()
+	p.cfws()
+	text, ok := p.dotAtomText()
+	if !ok {
+		p.restore(st)
+		return "", false
+	}
+	p.markDomainText()
+	p.cfws()
+	return text, true
+}
+
+// atom = [CFWS] 1*atext [CFWS]
+func (p *abnfParser) atom() (string, bool) {
+	st := p.save()
+	p.cfws()
+	start := p.pos
+	if !p.atextRun() {
+		p.restore(st)
+		return "", false
+	}
+	text := p.s[start:p.pos]
+	p.markDomainText()
+	p.cfws()
+	return text, true
+}
+
+// quoted-string = [CFWS] DQUOTE *([FWS] qcontent) [FWS] DQUOTE [CFWS]
+func (p *abnfParser) quotedString() (string, bool) {
+	st := p.save()
+	p.cfws()
+	if p.peek() != '"' {
+		p.restore(st)
+		return "", false
+	}
+	start := p.pos
+	p.pos++
+	for {
+		p.fws()
+		switch c := p.peek(); {
+		case c == '"':
+			p.pos++
+			text := p.s[start:p.pos]
+			p.cfws()
+			return text, true
+		case c == '\\':
+			if !p.quotedPair() {
+				p.restore(st)
+				return "", false
+			}
+		case isQtext(c):
+			p.pos++
+		default:
+			p.restore(st)
+			return "", false
+		}
+	}
+}
+
+// word = atom / quoted-string
+func (p *abnfParser) word() (string, bool, bool) {
+	if text, ok := p.atom(); ok {
+		return text, false, true
+	}
+	text, ok := p.quotedString()
+	return text, ok, ok
+}
+
+// local-part = dot-atom / quoted-string / obs-local-part, where obs-local-part = word *("." word).  Each alternative
+// must be followed by "@".
+func (p *abnfParser) localPart(obsolete bool) (string, bool, bool) {
+	st := p.save()
+	if text, ok := p.dotAtom(); ok && p.peek() == '@' {
+		return text, false, true
+	}
+	p.restore(st)
+
+	if text, ok := p.quotedString(); ok && p.peek() == '@' {
+		return text, true, true
+	}
+	p.restore(st)
+
+	if !obsolete {
+		return "", false, false
+	}
+
+	text, quoted, ok := p.word()
+	for ok && p.peek() == '.' {
+		p.pos++
+		var (
+			next       string
+			nextQuoted bool
+		)
+		if next, nextQuoted, ok = p.word(); ok {
+			text += "." + next
+			quoted = quoted || nextQuoted
+		}
+	}
+	if ok && p.peek() == '@' {
+		return text, quoted, true
+	}
+	p.restore(st)
+	return "", false, false
+}
+
+// domain-literal = [CFWS] "[" *([FWS] dtext) [FWS] "]" [CFWS]
+func (p *abnfParser) domainLiteral() (string, bool) {
+	st := p.save()
+	p.cfws()
+	if p.peek() != '[' {
+		p.restore(st)
+		return "", false
+	}
+	start := p.pos
+	p.pos++
+	for {
+		p.fws()
+		switch c := p.peek(); {
+		case c == ']':
+			p.pos++
+			text := p.s[start:p.pos]
+			p.markDomainText()
+			p.cfws()
+			return text, true
+		case isABNFDtext(c):
+			p.pos++
+		default:
+			p.restore(st)
+			return "", false
+		}
+	}
+}
+
+// domain = dot-atom / domain-literal / obs-domain, where obs-domain = atom *("." atom).  Each alternative must
+// consume the remainder of the input.
+func (p *abnfParser) domain(obsolete bool) (string, bool, bool) {
+	st := p.save()
+	if text, ok := p.dotAtom(); ok && p.pos == len(p.s) {
+		return text, false, true
+	}
+	p.restore(st)
+
+	if text, ok := p.domainLiteral(); ok && p.pos == len(p.s) {
+		return text, true, true
+	}
+	p.restore(st)
+
+	if !obsolete {
+		return "", false, false
+	}
+
+	text, ok := p.atom()
+	for ok && p.peek() == '.' {
+		p.pos++
+		var next string
+		if next, ok = p.atom(); ok {
+			text += "." + next
+		}
+	}
+	if ok && p.pos == len(p.s) {
+		return text, false, true
+	}
+	p.restore(st)
+	return "", false, false
+}
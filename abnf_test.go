@@ -0,0 +1,84 @@
+package emailvalidator_test
+
+import (
+	"errors"
+	"testing"
+
+	emailvalidator "github.com/dcarbone/go-email-validator"
+)
+
+func TestABNFEngine(t *testing.T) {
+	steps := []struct {
+		label    string
+		input    string
+		obsolete bool
+		local    string
+		domain   string
+		comment  string
+		trailing string
+		stripped string
+		invalid  bool
+	}{
+		{label: "simple", input: "simple@example.com", local: "simple", domain: "example.com", stripped: "simple@example.com"},
+		{label: "quoted", input: `"john..doe"@example.org`, local: `"john..doe"`, domain: "example.org", stripped: `"john..doe"@example.org`},
+		{label: "quoted-pair", input: `"a\"b"@example.org`, local: `"a\"b"`, domain: "example.org", stripped: `"a\"b"@example.org`},
+		{label: "literal", input: "user@[127.0.0.1]", local: "user", domain: "[127.0.0.1]", stripped: "user@[127.0.0.1]"},
+		{label: "comments", input: "(a)user(b)@(c)example.com(d)", local: "user", domain: "example.com", comment: "a", trailing: "d", stripped: "user@example.com"},
+		{label: "nested-comment", input: "user@example.com ((nested) comment)", local: "user", domain: "example.com", trailing: "(nested) comment", stripped: "user@example.com"},
+		{label: "escaped-paren-comment", input: `user@example.com (a\)b)`, local: "user", domain: "example.com", trailing: `a\)b`, stripped: "user@example.com"},
+		{label: "folded", input: "user@example.com\r\n (work)", local: "user", domain: "example.com", trailing: "work", stripped: "user@example.com"},
+		{label: "obsolete-local", input: `"john".doe@example.com`, obsolete: true, local: `"john".doe`, domain: "example.com", stripped: "john.doe@example.com"},
+		{label: "obsolete-domain", input: "user@example (c) . com", obsolete: true, local: "user", domain: "example.com", comment: "c", stripped: "user@example.com"},
+		{label: "obsolete-local-disallowed", input: `"john".doe@example.com`, invalid: true},
+		{label: "trailing-dot", input: "user.@example.com", invalid: true},
+		{label: "double-at", input: "a@b@example.com", invalid: true},
+		{label: "unterminated-comment", input: "user@example.com (work", invalid: true},
+		{label: "bare-crlf", input: "user@example.com\r\n", invalid: true},
+		{label: "bracket-in-literal", input: "user@[[127.0.0.1]", invalid: true},
+	}
+
+	for _, step := range steps {
+		t.Run(step.label, func(t *testing.T) {
+			engine := emailvalidator.ABNFEngine{AllowObsolete: step.obsolete}
+			res, err := emailvalidator.BuildResult(step.input, emailvalidator.WithEngine(engine))
+			if step.invalid {
+				if !errors.Is(err, emailvalidator.ErrEngineRejected) {
+					t.Errorf("expected ErrEngineRejected, saw %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if res.Local != step.local || res.Domain != step.domain || res.Stripped != step.stripped {
+				t.Errorf("unexpected result: %+v", res)
+			}
+			if res.Comment != step.comment || res.TrailingComment != step.trailing {
+				t.Errorf("expected comments %q and %q, saw %q and %q", step.comment, step.trailing, res.Comment, res.TrailingComment)
+			}
+		})
+	}
+}
+
+// TestABNFEngine_Oracle checks the default engine against the ABNF engine over the conformance corpus.  The grammar
+// alone never rejects an RFC-valid address, and where both engines accept an address they must agree on its stripped
+// form.
+func TestABNFEngine_Oracle(t *testing.T) {
+	opts := []emailvalidator.OptFunc{
+		emailvalidator.WithBehaviorVersion(emailvalidator.LatestBehaviorVersion),
+	}
+	for _, c := range emailvalidator.ConformanceCases() {
+		ref, refErr := emailvalidator.BuildResult(c.Input, append(opts, emailvalidator.WithEngine(emailvalidator.ABNFEngine{AllowObsolete: true}))...)
+		res, err := emailvalidator.BuildResult(c.Input, opts...)
+
+		if c.Valid && refErr != nil {
+			t.Errorf("%s: %q rejected by grammar: %v", c.ID, c.Input, refErr)
+		}
+		if err == nil && refErr != nil {
+			t.Errorf("%s: %q accepted by default engine but rejected by grammar: %v", c.ID, c.Input, refErr)
+		}
+		if err == nil && refErr == nil && res.Stripped != ref.Stripped {
+			t.Errorf("%s: expected stripped %q, saw %q", c.ID, ref.Stripped, res.Stripped)
+		}
+	}
+}
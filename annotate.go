@@ -0,0 +1,121 @@
+package emailvalidator
+
+import (
+	"html"
+	"strconv"
+	"strings"
+)
+
+// annotation is a single diagnostic to be rendered beneath the input
+type annotation struct {
+	pos int
+	msg string
+}
+
+// annotationsOf returns the errors and warnings of res in the order they should be rendered
+func annotationsOf(res Result) []annotation {
+	var out []annotation
+	for _, pe := range ErrorsOf(res.Err) {
+		out = append(out, annotation{pos: pe.Position, msg: explainMessage(pe)})
+	}
+	for _, pe := range res.Warnings {
+		out = append(out, annotation{pos: pe.Position, msg: "warning: " + explainMessage(pe)})
+	}
+	return out
+}
+
+// displayInput returns input with every byte that is not printable ASCII replaced by "?", so that each byte occupies
+// exactly one column
+func displayInput(input string) string {
+	b := []byte(input)
+	for i, c := range b {
+		if c < 32 || c > 126 {
+			b[i] = '?'
+		}
+	}
+	return string(b)
+}
+
+// caretLine returns a line with a "^" beneath each position in anns that falls within an input of length n
+func caretLine(anns []annotation, n int) string {
+	line := []byte(strings.Repeat(" ", n))
+	for _, a := range anns {
+		if a.pos >= 0 && a.pos < n {
+			line[a.pos] = '^'
+		}
+	}
+	return strings.TrimRight(string(line), " ")
+}
+
+// annotationLabel returns the label prefixing a diagnostic's message, e.g. "col 4: "
+func annotationLabel(a annotation) string {
+	if a.pos < 0 {
+		return ""
+	}
+	return "col " + strconv.Itoa(a.pos) + ": "
+}
+
+// Annotate renders the input of res with a caret beneath each position an error or warning was seen at, followed by
+// one line per diagnostic, in the style of compiler output:
+//
+//	jane doe@example.com
+//	    ^
+//	col 4: the address contains a character that is only allowed inside quotes
+//
+// Columns are zero-indexed byte offsets.  Bytes that are not printable ASCII are rendered as "?" to keep carets
+// aligned.  An empty string is returned if res has no diagnostics.
+func Annotate(res Result) string {
+	anns := annotationsOf(res)
+	if len(anns) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(displayInput(res.Input))
+	b.WriteByte('\n')
+	if carets := caretLine(anns, len(res.Input)); carets != "" {
+		b.WriteString(carets)
+		b.WriteByte('\n')
+	}
+	for _, a := range anns {
+		b.WriteString(annotationLabel(a))
+		b.WriteString(a.msg)
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// AnnotateHTML renders the same output as Annotate as an HTML <pre> element, suitable for admin UIs.  Each offending
+// character is additionally wrapped in a <mark> element.  All input is escaped.
+func AnnotateHTML(res Result) string {
+	anns := annotationsOf(res)
+	if len(anns) == 0 {
+		return ""
+	}
+
+	marked := make(map[int]bool, len(anns))
+	for _, a := range anns {
+		marked[a.pos] = true
+	}
+
+	var b strings.Builder
+	b.WriteString(`<pre class="email-annotation">`)
+	for i, c := range []byte(displayInput(res.Input)) {
+		if marked[i] {
+			b.WriteString("<mark>" + html.EscapeString(string(c)) + "</mark>")
+		} else {
+			b.WriteString(html.EscapeString(string(c)))
+		}
+	}
+	b.WriteByte('\n')
+	if carets := caretLine(anns, len(res.Input)); carets != "" {
+		b.WriteString(carets)
+		b.WriteByte('\n')
+	}
+	for _, a := range anns {
+		b.WriteString(html.EscapeString(annotationLabel(a) + a.msg))
+		b.WriteByte('\n')
+	}
+	b.WriteString("</pre>")
+	return b.String()
+}
@@ -0,0 +1,57 @@
+package emailvalidator_test
+
+import (
+	"testing"
+
+	emailvalidator "github.com/dcarbone/go-email-validator"
+)
+
+func TestAnnotate(t *testing.T) {
+	steps := []struct {
+		label string
+		input string
+		text  string
+		html  string
+	}{
+		{label: "ok", input: "jane@example.com"},
+		{
+			label: "positioned",
+			input: "jane doe@<example>.com",
+			text: "jane doe@<example>.com\n" +
+				"    ^    ^       ^\n" +
+				"col 4: the address contains a character that is only allowed inside quotes\n" +
+				"col 9: the address contains a character that is not allowed\n" +
+				"col 17: the address contains a character that is not allowed\n",
+			html: `<pre class="email-annotation">jane<mark> </mark>doe@<mark>&lt;</mark>example<mark>&gt;</mark>.com` + "\n" +
+				"    ^    ^       ^\n" +
+				"col 4: the address contains a character that is only allowed inside quotes\n" +
+				"col 9: the address contains a character that is not allowed\n" +
+				"col 17: the address contains a character that is not allowed\n" +
+				"</pre>",
+		},
+		{
+			label: "unpositioned",
+			input: "jane@",
+			text:  "jane@\nthe address is missing a domain\n",
+			html:  "<pre class=\"email-annotation\">jane@\nthe address is missing a domain\n</pre>",
+		},
+		{
+			label: "non-printable",
+			input: "ja\nne@example.com",
+			text:  "ja?ne@example.com\n  ^\ncol 2: the address contains a line break\n",
+			html:  "<pre class=\"email-annotation\">ja<mark>?</mark>ne@example.com\n  ^\ncol 2: the address contains a line break\n</pre>",
+		},
+	}
+
+	for _, step := range steps {
+		t.Run(step.label, func(t *testing.T) {
+			res, _ := emailvalidator.BuildResult(step.input)
+			if out := emailvalidator.Annotate(res); out != step.text {
+				t.Errorf("expected text:\n%s\nsaw:\n%s", step.text, out)
+			}
+			if out := emailvalidator.AnnotateHTML(res); out != step.html {
+				t.Errorf("expected html:\n%s\nsaw:\n%s", step.html, out)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package emailvalidator
+
+// Behavior versions gate correctness fixes that change which addresses are accepted.  The zero value of
+// ParseOptions.BehaviorVersion is equivalent to BehaviorVersion1, so upgrading this package never changes results
+// until a newer version is opted into.
+const (
+	// BehaviorVersion1 is the original parsing behavior
+	BehaviorVersion1 = 1
+
+	// BehaviorVersion2 rejects empty dot-atom segments that BehaviorVersion1 allowed: a period ending the local, or
+	// beginning or ending the domain, e.g. "user.@example.com" and "user@example.com."
+	BehaviorVersion2 = 2
+
+	// BehaviorVersion3 allows nested comments, e.g. "((a)b)", up to DefaultMaxCommentDepth, and quoted-pairs within
+	// comments, e.g. "(a\)b)"
+	BehaviorVersion3 = 3
+
+	// LatestBehaviorVersion is the most recent behavior version
+	LatestBehaviorVersion = BehaviorVersion3
+)
+
+// DefaultMaxCommentDepth is the maximum nesting depth of comments as of BehaviorVersion3, unless otherwise configured
+const DefaultMaxCommentDepth = 8
+
+// WithBehaviorVersion opts into the parsing behavior of version n, e.g. LatestBehaviorVersion
+func WithBehaviorVersion(n int) OptFunc {
+	return func(opt *ParseOptions) {
+		opt.BehaviorVersion = n
+	}
+}
+
+func (opt *ParseOptions) behaviorVersion() int {
+	if opt.BehaviorVersion < BehaviorVersion1 {
+		return BehaviorVersion1
+	}
+	return opt.BehaviorVersion
+}
+
+func (opt *ParseOptions) maxCommentDepth() int {
+	if opt.MaxCommentDepth > 0 {
+		return opt.MaxCommentDepth
+	} else if opt.behaviorVersion() >= BehaviorVersion3 {
+		return DefaultMaxCommentDepth
+	}
+	return 1
+}
@@ -0,0 +1,89 @@
+package emailvalidator_test
+
+import (
+	"errors"
+	"testing"
+
+	emailvalidator "github.com/dcarbone/go-email-validator"
+)
+
+func TestWithBehaviorVersion(t *testing.T) {
+	steps := []struct {
+		label string
+		input string
+		v1    error
+		v2    error
+	}{
+		{
+			label: "simple",
+			input: "user@example.com",
+		},
+		{
+			label: "trailing-dot-local",
+			input: "user.@example.com",
+			v2:    emailvalidator.ErrUnexpectedCharacter,
+		},
+		{
+			label: "trailing-dot-local-before-comment",
+			input: "user.(comment)@example.com",
+			v2:    emailvalidator.ErrUnexpectedCharacter,
+		},
+		{
+			label: "quoted-trailing-dot",
+			input: `"user."@example.com`,
+		},
+		{
+			label: "leading-dot-domain",
+			input: "user@.example.com",
+			v2:    emailvalidator.ErrUnexpectedCharacter,
+		},
+		{
+			label: "trailing-dot-domain",
+			input: "user@example.com.",
+			v2:    emailvalidator.ErrUnexpectedCharacter,
+		},
+		{
+			label: "trailing-dot-domain-before-comment",
+			input: "user@example.com.(work)",
+			v2:    emailvalidator.ErrUnexpectedCharacter,
+		},
+		{
+			label: "literal",
+			input: "user@[127.0.0.1]",
+		},
+		{
+			label: "double-dot-local",
+			input: "user..name@example.com",
+			v1:    emailvalidator.ErrInvalidUnquotedSequence,
+			v2:    emailvalidator.ErrInvalidUnquotedSequence,
+		},
+	}
+
+	for _, step := range steps {
+		t.Run(step.label, func(t *testing.T) {
+			for _, v := range []struct {
+				opts     []emailvalidator.OptFunc
+				expected error
+			}{
+				{nil, step.v1},
+				{[]emailvalidator.OptFunc{emailvalidator.WithBehaviorVersion(emailvalidator.BehaviorVersion1)}, step.v1},
+				{[]emailvalidator.OptFunc{emailvalidator.WithBehaviorVersion(emailvalidator.BehaviorVersion2)}, step.v2},
+			} {
+				_, err := emailvalidator.BuildResult(step.input, v.opts...)
+				if v.expected == nil && err != nil {
+					t.Errorf("unexpected error: %v", err)
+				} else if v.expected != nil && !errors.Is(err, v.expected) {
+					t.Errorf("expected %v, saw %v", v.expected, err)
+				}
+			}
+		})
+	}
+}
+
+func TestRunConformance_LatestBehavior(t *testing.T) {
+	for _, r := range emailvalidator.RunConformance(emailvalidator.WithBehaviorVersion(emailvalidator.LatestBehaviorVersion)) {
+		if !r.Pass {
+			t.Errorf("%s: %q expected valid=%t, saw err %v", r.Case.ID, r.Case.Input, r.Case.Valid, r.Err)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package emailvalidator
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+var (
+	ErrBidiControlCharacter = fmt.Errorf("%w: bidirectional control character, possible spoofing", ErrUnexpectedCharacter)
+)
+
+// isBidiControl returns true if r is one of the Unicode bidirectional formatting characters, which can be used to
+// render an address differently than it will be delivered, e.g. U+202E RIGHT-TO-LEFT OVERRIDE
+func isBidiControl(r rune) bool {
+	switch r {
+	case '\u061c', // arabic letter mark
+		'\u200e', // left-to-right mark
+		'\u200f', // right-to-left mark
+		'\u202a', // left-to-right embedding
+		'\u202b', // right-to-left embedding
+		'\u202c', // pop directional formatting
+		'\u202d', // left-to-right override
+		'\u202e', // right-to-left override
+		'\u2066', // left-to-right isolate
+		'\u2067', // right-to-left isolate
+		'\u2068', // first strong isolate
+		'\u2069': // pop directional isolate
+		return true
+	}
+	return false
+}
+
+// checkBidi screens email for bidirectional control characters, returning nil if none were seen.  They are never
+// valid within an address, and are reported in place of any other error as they indicate a likely spoofing attempt.
+//
+// Screening runs over the raw input and so does not wait on RFC 6532 support: without it, the parser still rejects
+// these characters, but only as an anonymous non-ASCII byte.
+func checkBidi(email string) *ParseError {
+	inDomain := false
+	for i, r := range email {
+		if r == '@' {
+			inDomain = true
+		}
+		if r < utf8.RuneSelf || !isBidiControl(r) {
+			continue
+		}
+		pe := newParseError(
+			fmt.Errorf("%w: %U at position %d", ErrBidiControlCharacter, r, i),
+			i,
+			string(r),
+			currentSegment(false, inDomain),
+		)
+		return &pe
+	}
+	return nil
+}
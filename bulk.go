@@ -0,0 +1,190 @@
+package emailvalidator
+
+import (
+	"errors"
+	"strings"
+)
+
+// Verdict is the overall outcome of validating a single address.
+type Verdict string
+
+const (
+	VerdictValid   Verdict = "valid"
+	VerdictInvalid Verdict = "invalid"
+
+	// VerdictUnknown is given to addresses that could not be validated, e.g. because a remote validator failed
+	VerdictUnknown Verdict = "unknown"
+)
+
+// VerdictOf returns the Verdict for the error returned by BuildResult or an EmailValidator
+func VerdictOf(err error) Verdict {
+	if err == nil {
+		return VerdictValid
+	}
+	if errors.Is(err, ErrRemoteValidator) {
+		return VerdictUnknown
+	}
+	return VerdictInvalid
+}
+
+type BulkOptions struct {
+	// ParseOptions are applied to every address validated during the bulk run
+	ParseOptions []OptFunc
+
+	// HasHeader, if true, will cause the first record of tabular input to be passed through as a header row by
+	// ValidateCSV, and skipped by WriteReports
+	HasHeader bool
+
+	// InputFormat is the format of input read by WriteReports.  Defaults to FormatAuto.
+	InputFormat InputFormat
+
+	// InputField is the header column or JSON member containing addresses within CSV, TSV, and JSONL input read by
+	// WriteReports.  Defaults to DefaultInputField.
+	InputField string
+
+	// Progress, if defined, is called after each address is validated with the number of addresses processed so far
+	// and the total number of addresses in the run.  Total will be -1 when it cannot be known ahead of time, as is the
+	// case with streamed input.
+	Progress func(done, total int)
+
+	// Sink, if defined, receives each Result as it is produced
+	Sink Sink
+
+	// Journal, if defined, is used to skip records completed by a previous run and to checkpoint this one
+	Journal Journal
+
+	// Canonicalize, if defined, is used in place of the parsed address to detect duplicates, and inputs sharing a
+	// canonical key are reported in Summary.DuplicateGroups.  ProviderKey collapses common provider-specific variants.
+	Canonicalize func(Result) string
+}
+
+type BulkOptFunc func(*BulkOptions)
+
+// WithParseOptions sets the OptFuncs used to parse each address in a bulk run
+func WithParseOptions(opts ...OptFunc) BulkOptFunc {
+	return func(opt *BulkOptions) {
+		opt.ParseOptions = append(opt.ParseOptions, opts...)
+	}
+}
+
+func HasHeader(opt *BulkOptions) {
+	opt.HasHeader = true
+}
+
+// WithProgress sets the callback executed after each address in a bulk run is validated
+func WithProgress(fn func(done, total int)) BulkOptFunc {
+	return func(opt *BulkOptions) {
+		opt.Progress = fn
+	}
+}
+
+// WithBulkCanonicalizer sets the function used to detect and report duplicates in a bulk run
+func WithBulkCanonicalizer(fn func(Result) string) BulkOptFunc {
+	return func(opt *BulkOptions) {
+		opt.Canonicalize = fn
+	}
+}
+
+// Summary contains aggregate counts for a bulk run
+type Summary struct {
+	// Total is the number of addresses seen
+	Total int
+
+	// Verdicts contains the number of addresses seen per Verdict
+	Verdicts map[Verdict]int
+
+	// Errors contains the number of times each error code was seen
+	Errors map[ErrorCode]int
+
+	// Domains contains the number of addresses seen per lower-cased domain
+	Domains map[string]int
+
+	// Duplicates is the number of addresses seen more than once.  Each repeat beyond the first occurrence is counted.
+	Duplicates int
+
+	// DuplicateGroups maps each canonical key seen more than once to every input that collapsed to it, in the order
+	// seen.  It is only populated if BulkOptions.Canonicalize is defined.
+	DuplicateGroups map[string][]string
+
+	// Resumed is the number of records skipped because a Journal marked them complete in a previous run
+	Resumed int
+
+	canonicalize func(Result) string
+
+	// seen maps each key to its first input, which is only retained when canonicalizing
+	seen map[string]string
+}
+
+func (s *Summary) add(res Result, err error) {
+	if s.seen == nil {
+		s.Verdicts = make(map[Verdict]int)
+		s.Errors = make(map[ErrorCode]int)
+		s.Domains = make(map[string]int)
+		s.seen = make(map[string]string)
+	}
+
+	s.Total++
+	s.Verdicts[VerdictOf(err)]++
+
+	for _, e := range flattenErrors(err) {
+		s.Errors[CodeOf(e)]++
+	}
+
+	if res.Domain != "" {
+		s.Domains[strings.ToLower(res.Domain)]++
+	}
+
+	key := res.Local + "@" + strings.ToLower(res.Domain)
+	if s.canonicalize == nil || res.Domain == "" {
+		if _, ok := s.seen[key]; ok {
+			s.Duplicates++
+		} else {
+			s.seen[key] = ""
+		}
+		return
+	}
+
+	key = s.canonicalize(res)
+	first, ok := s.seen[key]
+	if !ok {
+		s.seen[key] = res.Input
+		return
+	}
+	s.Duplicates++
+	if s.DuplicateGroups == nil {
+		s.DuplicateGroups = make(map[string][]string)
+	}
+	if _, ok = s.DuplicateGroups[key]; !ok {
+		s.DuplicateGroups[key] = []string{first}
+	}
+	s.DuplicateGroups[key] = append(s.DuplicateGroups[key], res.Input)
+}
+
+func buildBulkOptions(opts []BulkOptFunc) BulkOptions {
+	var bulkOpts BulkOptions
+	for _, fn := range opts {
+		fn(&bulkOpts)
+	}
+	return bulkOpts
+}
+
+// flattenErrors returns the list of individual errors contained within err
+func flattenErrors(err error) []error {
+	if err == nil {
+		return nil
+	}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		return joined.Unwrap()
+	}
+	return []error{err}
+}
+
+// diagnosticString renders err as a single line, each individual error separated by "; "
+func diagnosticString(err error) string {
+	errs := flattenErrors(err)
+	out := make([]string, len(errs))
+	for i, e := range errs {
+		out[i] = e.Error()
+	}
+	return strings.Join(out, "; ")
+}
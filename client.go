@@ -0,0 +1,70 @@
+package emailvalidator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+var (
+	ErrRemoteValidator = errors.New("remote validator request failed")
+)
+
+// Client validates addresses against a remote Handler.  It implements EmailValidator, so it may be used anywhere a
+// local Validator is.
+type Client struct {
+	endpoint   string
+	httpClient *http.Client
+}
+
+// NewClient creates a Client targeting the Handler served at endpoint.  If httpClient is nil, http.DefaultClient is
+// used.
+func NewClient(endpoint string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{endpoint: endpoint, httpClient: httpClient}
+}
+
+// Validate implements EmailValidator.  Validation errors returned by the remote Handler are reconstructed such that
+// errors.Is continues to work against this package's sentinel errors.
+func (c *Client) Validate(ctx context.Context, email string) (Result, error) {
+	u, err := url.Parse(c.endpoint)
+	if err != nil {
+		return Result{Input: email}, fmt.Errorf("%w: invalid endpoint: %v", ErrRemoteValidator, err)
+	}
+	q := u.Query()
+	q.Set("email", email)
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return Result{Input: email}, fmt.Errorf("%w: %v", ErrRemoteValidator, err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return Result{Input: email}, fmt.Errorf("%w: %v", ErrRemoteValidator, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return Result{Input: email}, fmt.Errorf("%w: status %d: %s", ErrRemoteValidator, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var vr ValidateResponse
+	if err = json.NewDecoder(resp.Body).Decode(&vr); err != nil {
+		return Result{Input: email}, fmt.Errorf("%w: error decoding response: %v", ErrRemoteValidator, err)
+	}
+
+	// Result.Err is not serialized, so is rebuilt from the response's errors for consumers of the Result alone, e.g. a
+	// Scorer
+	vr.Result.Err = vr.Errors.Err()
+	return vr.Result, vr.Result.Err
+}
@@ -0,0 +1,161 @@
+package emailvalidator
+
+import (
+	"strings"
+)
+
+// Cluster is a group of addresses that likely refer to the same subscriber
+type Cluster struct {
+	// Keys contains the distinct canonical keys within the cluster.  More than one key indicates the cluster was
+	// formed from near-identical domains, e.g. "user@gmail.com" and "user@gmial.com".
+	Keys []string
+
+	// Members contains every input address within the cluster, grouped by key
+	Members []string
+}
+
+type ClusterOptions struct {
+	// ParseOptions are applied when parsing each address
+	ParseOptions []OptFunc
+
+	// Canonicalize computes each address's canonical key.  Defaults to NormalizeKey.
+	Canonicalize func(Result) string
+
+	// DomainDistance is used to compare the domains of addresses sharing a local part.  Defaults to KeyboardDistance.
+	DomainDistance DistanceFunc
+
+	// DomainThreshold is the maximum distance at which two domains are considered the same.  Defaults to 1.  Set to a
+	// negative value to only cluster on canonical key.
+	DomainThreshold float64
+}
+
+type ClusterOptFunc func(*ClusterOptions)
+
+func WithClusterParseOptions(opts ...OptFunc) ClusterOptFunc {
+	return func(opt *ClusterOptions) {
+		opt.ParseOptions = append(opt.ParseOptions, opts...)
+	}
+}
+
+func WithClusterCanonicalizer(fn func(Result) string) ClusterOptFunc {
+	return func(opt *ClusterOptions) {
+		opt.Canonicalize = fn
+	}
+}
+
+func WithDomainDistance(fn DistanceFunc, threshold float64) ClusterOptFunc {
+	return func(opt *ClusterOptions) {
+		opt.DomainDistance = fn
+		opt.DomainThreshold = threshold
+	}
+}
+
+// ClusterAddresses groups emails whose canonical keys are equal, or whose local parts are equal and domains are within
+// the configured distance of one another.  Only clusters with more than one member are returned, ordered by the first
+// appearance of any of their members.  Addresses that fail to parse are not clustered.
+func ClusterAddresses(emails []string, opts ...ClusterOptFunc) []Cluster {
+	clusterOpts := ClusterOptions{DomainThreshold: 1}
+	for _, fn := range opts {
+		fn(&clusterOpts)
+	}
+	if clusterOpts.Canonicalize == nil {
+		clusterOpts.Canonicalize = NormalizeKey
+	}
+	if clusterOpts.DomainDistance == nil {
+		clusterOpts.DomainDistance = KeyboardDistance
+	}
+
+	type group struct {
+		key     string
+		local   string
+		domain  string
+		members []string
+	}
+
+	var (
+		groups  []*group
+		byKey   = make(map[string]int)
+		byLocal = make(map[string][]int)
+	)
+
+	// group by canonical key
+	for _, email := range emails {
+		res, err := BuildResult(email, clusterOpts.ParseOptions...)
+		if err != nil {
+			continue
+		}
+		key := clusterOpts.Canonicalize(res)
+		if idx, ok := byKey[key]; ok {
+			groups[idx].members = append(groups[idx].members, email)
+			continue
+		}
+		byKey[key] = len(groups)
+		local := minimalLocal(res.Local, res.Quoted)
+		byLocal[local] = append(byLocal[local], len(groups))
+		groups = append(groups, &group{
+			key:     key,
+			local:   local,
+			domain:  strings.ToLower(res.Domain),
+			members: []string{email},
+		})
+	}
+
+	// union groups sharing a local part with near-identical domains
+	parent := make([]int, len(groups))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	if clusterOpts.DomainThreshold >= 0 {
+		for _, idxs := range byLocal {
+			for x := 0; x < len(idxs); x++ {
+				for y := x + 1; y < len(idxs); y++ {
+					a, b := groups[idxs[x]], groups[idxs[y]]
+					if clusterOpts.DomainDistance(a.domain, b.domain) <= clusterOpts.DomainThreshold {
+						ra, rb := find(idxs[x]), find(idxs[y])
+						// always root at the earliest group, to keep output ordering stable
+						if ra < rb {
+							parent[rb] = ra
+						} else {
+							parent[ra] = rb
+						}
+					}
+				}
+			}
+		}
+	}
+
+	// build clusters in order of first appearance
+	var (
+		out    []Cluster
+		byRoot = make(map[int]int)
+	)
+	for i, g := range groups {
+		root := find(i)
+		idx, ok := byRoot[root]
+		if !ok {
+			idx = len(out)
+			byRoot[root] = idx
+			out = append(out, Cluster{})
+		}
+		out[idx].Keys = append(out[idx].Keys, g.key)
+		out[idx].Members = append(out[idx].Members, g.members...)
+	}
+
+	// drop singletons
+	clusters := out[:0]
+	for _, c := range out {
+		if len(c.Members) > 1 {
+			clusters = append(clusters, c)
+		}
+	}
+
+	return clusters
+}
@@ -0,0 +1,42 @@
+package emailvalidator_test
+
+import (
+	"reflect"
+	"testing"
+
+	emailvalidator "github.com/dcarbone/go-email-validator"
+)
+
+func TestClusterAddresses(t *testing.T) {
+	clusters := emailvalidator.ClusterAddresses([]string{
+		"user@gmail.com",
+		"someone@example.com",
+		"user@GMAIL.com",
+		"user@gmaul.com",
+		"other@yahoo.com",
+		"not-an-address",
+		"other@hotmail.com",
+	})
+
+	expected := []emailvalidator.Cluster{
+		{
+			Keys:    []string{"user@gmail.com", "user@gmaul.com"},
+			Members: []string{"user@gmail.com", "user@GMAIL.com", "user@gmaul.com"},
+		},
+	}
+	if !reflect.DeepEqual(clusters, expected) {
+		t.Errorf("expected %+v, saw %+v", expected, clusters)
+	}
+}
+
+func TestClusterAddresses_CanonicalOnly(t *testing.T) {
+	for _, distance := range []emailvalidator.DistanceFunc{emailvalidator.Levenshtein, nil} {
+		clusters := emailvalidator.ClusterAddresses(
+			[]string{"user@gmail.com", "user@gmaul.com", "(c)user@gmail.com"},
+			emailvalidator.WithDomainDistance(distance, -1),
+		)
+		if len(clusters) != 1 || len(clusters[0].Keys) != 1 || len(clusters[0].Members) != 2 {
+			t.Errorf("unexpected clusters: %+v", clusters)
+		}
+	}
+}
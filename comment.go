@@ -0,0 +1,59 @@
+package emailvalidator
+
+// CommentPlacement identifies where within an address a comment was seen
+type CommentPlacement string
+
+const (
+	// CommentBeforeLocal is a comment preceding the local, e.g. "(a)user@example.com"
+	CommentBeforeLocal CommentPlacement = "before_local"
+
+	// CommentAfterLocal is a comment within or following the local, e.g. "user(a)@example.com"
+	CommentAfterLocal CommentPlacement = "after_local"
+
+	// CommentBeforeDomain is a comment preceding the domain, e.g. "user@(a)example.com"
+	CommentBeforeDomain CommentPlacement = "before_domain"
+
+	// CommentAfterDomain is a comment following the domain, e.g. "user@example.com (a)"
+	CommentAfterDomain CommentPlacement = "after_domain"
+)
+
+// AddressComment is a record of a single top-level comment seen in an address
+type AddressComment struct {
+	// Text is the full text of the comment minus its outermost parentheses, including any nested comments
+	Text string
+
+	// Depth is the deepest level of nesting seen within the comment, where 1 indicates no nesting
+	Depth int
+
+	// Position is the zero-indexed offset of the comment's opening parenthesis within the input
+	Position int
+
+	// Placement is where within the address the comment was seen
+	Placement CommentPlacement
+}
+
+// commentPlacement classifies a comment opened while parsing the local or domain side of an address, given the
+// lengths of the local and domain seen so far
+func commentPlacement(inLocal bool, localLen, domainLen int) CommentPlacement {
+	switch {
+	case inLocal && localLen == 0:
+		return CommentBeforeLocal
+	case inLocal:
+		return CommentAfterLocal
+	case domainLen == 0:
+		return CommentBeforeDomain
+	default:
+		return CommentAfterDomain
+	}
+}
+
+// appendComment appends chr to the text of the comment currently being parsed.  Comments following any domain text
+// are trailing.
+func appendComment(res *Result, chr string, trailing bool) {
+	if trailing {
+		res.TrailingComment += chr
+	} else {
+		res.Comment += chr
+	}
+	res.Comments[len(res.Comments)-1].Text += chr
+}
@@ -0,0 +1,65 @@
+package emailvalidator
+
+// ConfidenceLevel buckets a confidence score, so callers may, e.g., apply high confidence corrections automatically
+// and prompt the user to confirm the rest
+type ConfidenceLevel string
+
+const (
+	// ConfidenceHigh corrections are safe to apply without confirmation
+	ConfidenceHigh ConfidenceLevel = "high"
+
+	// ConfidenceMedium corrections are likely, but should be confirmed
+	ConfidenceMedium ConfidenceLevel = "medium"
+
+	// ConfidenceLow corrections are plausible, and must be confirmed
+	ConfidenceLow ConfidenceLevel = "low"
+)
+
+// ConfidenceLevelOf returns the ConfidenceLevel of score: ConfidenceHigh at 0.9 and above, ConfidenceMedium at 0.5
+// and above, and ConfidenceLow otherwise
+func ConfidenceLevelOf(score float64) ConfidenceLevel {
+	switch {
+	case score >= 0.9:
+		return ConfidenceHigh
+	case score >= 0.5:
+		return ConfidenceMedium
+	default:
+		return ConfidenceLow
+	}
+}
+
+// fixConfidence contains the confidence of each FixKind whose correctness does not depend on the input.  Removing
+// whitespace or invisible characters never changes the intended address, while decoding and punctuation repairs are
+// occasionally applied to input that was meant literally.
+var fixConfidence = map[FixKind]float64{
+	FixTrim:          1,
+	FixInvisible:     1,
+	FixPunctuation:   0.95,
+	FixPercentDecode: 0.9,
+	FixHTMLDecode:    0.9,
+	FixDoubleAt:      0.9,
+}
+
+// newFix returns a Fix of kind with its confidence populated from fixConfidence
+func newFix(kind FixKind, position int, from, to string) Fix {
+	return Fix{Kind: kind, Position: position, From: from, To: to, Confidence: fixConfidence[kind]}
+}
+
+// knownDomainConfidence caps the confidence of suggestions replacing a known domain
+const knownDomainConfidence = 0.3
+
+// suggestionConfidence scores a suggestion of candidate at distance.  The proportion of candidate left unchanged is
+// the base score, so a single mistyped character is more telling within a long domain than a short one.  It is then
+// scaled by the margin over the nearest other candidate at distance other: the full score is kept once other is at
+// least twice as far, and equally close candidates halve it.  Finally, it is capped if the input domain is known, as
+// e.g. "ymail.com" lies one keystroke from "gmail.com" but is far more often intended than mistyped.
+func suggestionConfidence(distance, other float64, candidate string, known bool) float64 {
+	score := max(0, 1-distance/float64(len(candidate)))
+	if distance > 0 {
+		score *= min(1, other/(2*distance))
+	}
+	if known {
+		score = min(score, knownDomainConfidence)
+	}
+	return score
+}
@@ -0,0 +1,85 @@
+package emailvalidator_test
+
+import (
+	"testing"
+
+	emailvalidator "github.com/dcarbone/go-email-validator"
+)
+
+func TestConfidenceLevelOf(t *testing.T) {
+	tests := []struct {
+		score float64
+		want  emailvalidator.ConfidenceLevel
+	}{
+		{1, emailvalidator.ConfidenceHigh},
+		{0.9, emailvalidator.ConfidenceHigh},
+		{0.89, emailvalidator.ConfidenceMedium},
+		{0.5, emailvalidator.ConfidenceMedium},
+		{0.49, emailvalidator.ConfidenceLow},
+		{0, emailvalidator.ConfidenceLow},
+	}
+	for _, tt := range tests {
+		if got := emailvalidator.ConfidenceLevelOf(tt.score); got != tt.want {
+			t.Errorf("ConfidenceLevelOf(%v): expected %s, saw %s", tt.score, tt.want, got)
+		}
+	}
+}
+
+func TestSuggestion_Confidence(t *testing.T) {
+	s := emailvalidator.NewSuggester(emailvalidator.WithDistanceFunc(emailvalidator.Levenshtein))
+
+	out := s.SuggestDomain("gmai.com")
+	if len(out) == 0 || out[0].Domain != "gmail.com" || out[0].Confidence != 8.0/9 {
+		t.Fatalf("unexpected suggestions %+v", out)
+	}
+	if out[0].Level() != emailvalidator.ConfidenceMedium {
+		t.Errorf("expected %s confidence, saw %s", emailvalidator.ConfidenceMedium, out[0].Level())
+	}
+
+	// equally close candidates split their confidence
+	s = emailvalidator.NewSuggester(
+		emailvalidator.WithDistanceFunc(emailvalidator.Levenshtein),
+		emailvalidator.WithDomains("aaaa.com", "bbbb.com"),
+	)
+	out = s.SuggestDomain("abab.com")
+	if len(out) != 2 || out[0].Confidence != 0.375 || out[1].Confidence != 0.375 {
+		t.Errorf("expected split confidence, saw %+v", out)
+	}
+}
+
+func TestSuggestion_ConfidenceCalibration(t *testing.T) {
+	tests := []struct {
+		domain    string
+		candidate string
+		want      emailvalidator.ConfidenceLevel
+	}{
+		// a clear typo of a candidate, well ahead of the runner-up
+		{"gmaul.com", "gmail.com", emailvalidator.ConfidenceHigh},
+		{"outlok.com", "outlook.com", emailvalidator.ConfidenceHigh},
+
+		// nearly as close to another candidate
+		{"gamil.com", "gmail.com", emailvalidator.ConfidenceLow},
+
+		// known domains that are themselves a typo away from a candidate
+		{"ymail.com", "gmail.com", emailvalidator.ConfidenceLow},
+		{"gmx.net", "gmx.de", emailvalidator.ConfidenceLow},
+		{"live.ca", "live.com", emailvalidator.ConfidenceLow},
+		{"yahoo.ca", "yahoo.com", emailvalidator.ConfidenceLow},
+		{"aim.com", "aol.com", emailvalidator.ConfidenceLow},
+	}
+	s := emailvalidator.NewSuggester()
+	for _, tt := range tests {
+		out := s.SuggestDomain(tt.domain)
+		if len(out) == 0 || out[0].Domain != tt.candidate {
+			t.Errorf("%s: expected %s to be suggested first, saw %+v", tt.domain, tt.candidate, out)
+		} else if out[0].Level() != tt.want {
+			t.Errorf("%s: expected %s confidence, saw %s (%v)", tt.domain, tt.want, out[0].Level(), out[0].Confidence)
+		}
+	}
+
+	// known domains may be replaced entirely
+	s = emailvalidator.NewSuggester(emailvalidator.WithKnownDomains())
+	if out := s.SuggestDomain("ymail.com"); len(out) == 0 || out[0].Level() != emailvalidator.ConfidenceHigh {
+		t.Errorf("expected high confidence without known domains, saw %+v", out)
+	}
+}
@@ -0,0 +1,31 @@
+package emailvalidator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Config is the declarative, file-based form of this package's options.  It is versioned loosely by section, so new
+// sections may be added without breaking existing files.
+//
+//	{
+//	  "parse": {
+//	    "track_character_positions": true
+//	  }
+//	}
+type Config struct {
+	Parse ParseOptions `json:"parse"`
+}
+
+// LoadConfig decodes a JSON-encoded Config from r.  Unknown keys are rejected so that typos in policy files surface
+// as errors rather than silently-ignored settings.
+func LoadConfig(r io.Reader) (ParseOptions, error) {
+	var cfg Config
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&cfg); err != nil {
+		return ParseOptions{}, fmt.Errorf("error decoding config: %w", err)
+	}
+	return cfg.Parse, nil
+}
@@ -0,0 +1,32 @@
+package emailvalidator_test
+
+import (
+	"strings"
+	"testing"
+
+	emailvalidator "github.com/dcarbone/go-email-validator"
+)
+
+func TestLoadConfig(t *testing.T) {
+	opts, err := emailvalidator.LoadConfig(strings.NewReader(`{"parse": {"track_character_positions": true}}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !opts.TrackCharacterPositions {
+		t.Error("expected TrackCharacterPositions to be true")
+	}
+
+	res, err := emailvalidator.BuildResult("simple@example.com", emailvalidator.WithOptions(opts))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.CharacterPositions == nil {
+		t.Error("expected CharacterPositions to be populated")
+	}
+}
+
+func TestLoadConfig_UnknownField(t *testing.T) {
+	if _, err := emailvalidator.LoadConfig(strings.NewReader(`{"parse": {"track_positions": true}}`)); err == nil {
+		t.Error("expected error for unknown field")
+	}
+}
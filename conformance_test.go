@@ -0,0 +1,67 @@
+package emailvalidator_test
+
+import (
+	"testing"
+
+	emailvalidator "github.com/dcarbone/go-email-validator"
+)
+
+func TestConformanceCases(t *testing.T) {
+	cases := emailvalidator.ConformanceCases()
+	if len(cases) == 0 {
+		t.Fatal("expected embedded cases")
+	}
+	seen := make(map[string]bool)
+	for _, c := range cases {
+		if c.Source == "" {
+			t.Errorf("case %q has no source", c.ID)
+		}
+		if seen[c.ID] {
+			t.Errorf("duplicate case id %q", c.ID)
+		}
+		seen[c.ID] = true
+	}
+}
+
+func TestRunConformance(t *testing.T) {
+	// BehaviorVersion1 accepts empty dot-atom segments at the end of the local and at either end of the domain
+	v1Divergent := map[string]bool{
+		"boundary-trailing-dot-local":  true,
+		"boundary-leading-dot-domain":  true,
+		"boundary-trailing-dot-domain": true,
+	}
+
+	steps := []struct {
+		label     string
+		opts      []emailvalidator.OptFunc
+		divergent map[string]bool
+	}{
+		{label: "default", divergent: v1Divergent},
+		{label: "latest", opts: []emailvalidator.OptFunc{emailvalidator.WithBehaviorVersion(emailvalidator.LatestBehaviorVersion)}},
+	}
+	for _, step := range steps {
+		t.Run(step.label, func(t *testing.T) {
+			results := emailvalidator.RunConformance(step.opts...)
+			if len(results) != len(emailvalidator.ConformanceCases()) {
+				t.Fatalf("expected a result per case, saw %d", len(results))
+			}
+			for _, r := range results {
+				if r.Pass == step.divergent[r.Case.ID] {
+					t.Errorf("%s: %q expected valid=%t, saw err %v", r.Case.ID, r.Case.Input, r.Case.Valid != step.divergent[r.Case.ID], r.Err)
+				}
+			}
+		})
+	}
+}
+
+func TestRunConformance_Profile(t *testing.T) {
+	var failed int
+	for _, r := range emailvalidator.RunConformance(emailvalidator.PresetGmailRules) {
+		if !r.Pass {
+			failed++
+		}
+	}
+	if failed == 0 {
+		t.Error("expected the gmail profile to reject some RFC-valid cases")
+	}
+}
@@ -0,0 +1,41 @@
+// Command cshared exports this package's validator through a C ABI, so that non-Go services, e.g. Python via ctypes
+// or Ruby via FFI, may embed it without running a network service.  Build it with:
+//
+//	go build -buildmode=c-shared -o libemailvalidator.so ./cshared
+package main
+
+/*
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"encoding/json"
+	"time"
+	"unsafe"
+
+	emailvalidator "github.com/dcarbone/go-email-validator"
+)
+
+// ValidateJSON validates the NUL-terminated address email, returning a JSON-encoded emailvalidator.Report.  The
+// returned string is allocated with malloc, and must be released with FreeString.
+//
+//export ValidateJSON
+func ValidateJSON(email *C.char) *C.char {
+	start := time.Now()
+	res, err := emailvalidator.BuildResult(C.GoString(email))
+	b, jsonErr := json.Marshal(emailvalidator.NewReport(res, err, time.Since(start)))
+	if jsonErr != nil {
+		return nil
+	}
+	return C.CString(string(b))
+}
+
+// FreeString releases a string returned by ValidateJSON
+//
+//export FreeString
+func FreeString(s *C.char) {
+	C.free(unsafe.Pointer(s))
+}
+
+func main() {}
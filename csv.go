@@ -0,0 +1,135 @@
+package emailvalidator
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+)
+
+var (
+	ErrCSVColumnMissing = errors.New("csv record does not contain address column")
+)
+
+// ValidateCSV streams CSV records from r, validating the address found in the provided zero-indexed column of each
+// record.  If w is non-nil, each record is written to it with two additional columns appended: the Verdict and a
+// "; "-separated list of diagnostics.
+//
+// If a Sink is configured, each Result is also delivered to it, and it is flushed once the run ends, whether or not
+// every record was processed.
+//
+// If a Journal is configured, records it marks complete are skipped, and every processed record is marked complete
+// once its output is flushed to w and any Sink is flushed.
+//
+// If ctx is cancelled mid-run, all output written thus far is flushed and the context's error is returned alongside a
+// Summary of the records processed before cancellation.
+func ValidateCSV(ctx context.Context, r io.Reader, w io.Writer, column int, opts ...BulkOptFunc) (summary Summary, err error) {
+	var (
+		cw *csv.Writer
+
+		bulkOpts = buildBulkOptions(opts)
+		cr       = csv.NewReader(r)
+	)
+	summary.canonicalize = bulkOpts.Canonicalize
+
+	// partial results are delivered however the run ends
+	defer func() { err = flushSink(bulkOpts.Sink, err) }()
+
+	// marketing lists are rarely consistent in their column counts
+	cr.FieldsPerRecord = -1
+
+	if w != nil {
+		cw = csv.NewWriter(w)
+	}
+
+	for row := 0; ; row++ {
+		if err := ctx.Err(); err != nil {
+			return summary, flushCSV(cw, err)
+		}
+
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return summary, flushCSV(cw, fmt.Errorf("error reading csv: %w", err))
+		}
+
+		var key string
+		if bulkOpts.Journal != nil {
+			key = journalKey(row, record...)
+			if bulkOpts.Journal.Completed(key) {
+				summary.Resumed++
+				continue
+			}
+		}
+
+		// pass header through untouched
+		if row == 0 && bulkOpts.HasHeader {
+			if cw != nil {
+				if err = cw.Write(append(record, "verdict", "diagnostics")); err != nil {
+					return summary, fmt.Errorf("error writing csv: %w", err)
+				}
+			}
+			if err = completeCSVRecord(cw, bulkOpts.Sink, bulkOpts.Journal, key); err != nil {
+				return summary, err
+			}
+			continue
+		}
+
+		var res Result
+		if column < 0 || column >= len(record) {
+			err = fmt.Errorf("%w: row %d has %d columns", ErrCSVColumnMissing, row, len(record))
+			res.Err = err
+		} else {
+			res, err = BuildResult(record[column], bulkOpts.ParseOptions...)
+		}
+
+		summary.add(res, err)
+
+		if cw != nil {
+			if err = cw.Write(append(record, string(VerdictOf(err)), diagnosticString(err))); err != nil {
+				return summary, fmt.Errorf("error writing csv: %w", err)
+			}
+		}
+
+		if bulkOpts.Sink != nil {
+			if err = bulkOpts.Sink.OnResult(res); err != nil {
+				return summary, flushCSV(cw, err)
+			}
+		}
+
+		if err = completeCSVRecord(cw, bulkOpts.Sink, bulkOpts.Journal, key); err != nil {
+			return summary, err
+		}
+
+		if bulkOpts.Progress != nil {
+			bulkOpts.Progress(summary.Total, -1)
+		}
+	}
+
+	return summary, flushCSV(cw, nil)
+}
+
+// completeCSVRecord flushes cw and sink so the record's output is durable, then marks key complete in journal
+func completeCSVRecord(cw *csv.Writer, sink Sink, journal Journal, key string) error {
+	if journal == nil {
+		return nil
+	}
+	if err := flushCSV(cw, nil); err != nil {
+		return err
+	}
+	return completeRecord(sink, journal, key)
+}
+
+// flushCSV flushes any buffered output in cw, returning err or any error seen during the flush
+func flushCSV(cw *csv.Writer, err error) error {
+	if cw == nil {
+		return err
+	}
+	cw.Flush()
+	if ferr := cw.Error(); ferr != nil {
+		return errors.Join(err, fmt.Errorf("error writing csv: %w", ferr))
+	}
+	return err
+}
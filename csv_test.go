@@ -0,0 +1,151 @@
+package emailvalidator_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	emailvalidator "github.com/dcarbone/go-email-validator"
+)
+
+func TestValidateCSV(t *testing.T) {
+	const input = `name,email
+simple,simple@example.com
+broken,abc.example.com
+short
+again,simple@EXAMPLE.com
+`
+
+	var progress []int
+
+	out := new(bytes.Buffer)
+
+	summary, err := emailvalidator.ValidateCSV(
+		context.Background(),
+		strings.NewReader(input),
+		out,
+		1,
+		emailvalidator.HasHeader,
+		emailvalidator.WithProgress(func(done, total int) {
+			progress = append(progress, done)
+			if total != -1 {
+				t.Errorf("expected unknown total, saw %d", total)
+			}
+		}),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if summary.Total != 4 ||
+		summary.Verdicts[emailvalidator.VerdictValid] != 2 ||
+		summary.Verdicts[emailvalidator.VerdictInvalid] != 2 {
+		t.Errorf("unexpected summary: %+v", summary)
+	}
+	if len(progress) != 4 || progress[3] != 4 {
+		t.Errorf("unexpected progress calls: %v", progress)
+	}
+	if summary.Domains["example.com"] != 2 {
+		t.Errorf("expected 2 example.com addresses, saw %d", summary.Domains["example.com"])
+	}
+	if summary.Duplicates != 1 {
+		t.Errorf("expected 1 duplicate, saw %d", summary.Duplicates)
+	}
+	if summary.Errors[emailvalidator.CodeZeroLengthDomain] != 1 {
+		t.Errorf("expected 1 zero-length domain error, saw %v", summary.Errors)
+	}
+	if summary.Errors[emailvalidator.CodeCSVColumnMissing] != 1 {
+		t.Errorf("expected 1 missing column error, saw %v", summary.Errors)
+	}
+
+	cr := csv.NewReader(out)
+	cr.FieldsPerRecord = -1
+	records, err := cr.ReadAll()
+	if err != nil {
+		t.Fatalf("error reading output: %v", err)
+	}
+	if len(records) != 5 {
+		t.Fatalf("expected 5 output records, saw %d", len(records))
+	}
+	if h := records[0]; h[len(h)-2] != "verdict" || h[len(h)-1] != "diagnostics" {
+		t.Errorf("unexpected header: %v", h)
+	}
+	if r := records[1]; r[2] != string(emailvalidator.VerdictValid) || r[3] != "" {
+		t.Errorf("unexpected valid row: %v", r)
+	}
+	if r := records[2]; r[2] != string(emailvalidator.VerdictInvalid) || r[3] == "" {
+		t.Errorf("unexpected invalid row: %v", r)
+	}
+	if r := records[3]; r[1] != string(emailvalidator.VerdictInvalid) {
+		t.Errorf("unexpected short row: %v", r)
+	}
+}
+
+func TestValidateCSV_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := emailvalidator.ValidateCSV(ctx, strings.NewReader("simple@example.com\n"), nil, 0)
+	if err == nil {
+		t.Fatal("expected error from cancelled context")
+	}
+}
+
+func TestValidateCSV_CancelledMidRun(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	out := new(bytes.Buffer)
+
+	summary, err := emailvalidator.ValidateCSV(
+		ctx,
+		strings.NewReader("a@example.com\nb@example.com\nc@example.com\n"),
+		out,
+		0,
+		emailvalidator.WithProgress(func(done, _ int) {
+			if done == 2 {
+				cancel()
+			}
+		}),
+	)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, saw %v", err)
+	}
+	if summary.Total != 2 {
+		t.Errorf("expected 2 processed records, saw %d", summary.Total)
+	}
+	if lines := strings.Count(out.String(), "\n"); lines != 2 {
+		t.Errorf("expected 2 flushed records, saw %d", lines)
+	}
+}
+
+func TestValidateCSV_DuplicateGroups(t *testing.T) {
+	input := "j.doe+x@gmail.com\njdoe@gmail.com\nother@example.com\nJDoe@googlemail.com\n"
+
+	summary, err := emailvalidator.ValidateCSV(context.Background(), strings.NewReader(input), nil, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if summary.Duplicates != 0 || summary.DuplicateGroups != nil {
+		t.Errorf("expected no duplicates without canonicalizer, saw %+v", summary)
+	}
+
+	summary, err = emailvalidator.ValidateCSV(
+		context.Background(),
+		strings.NewReader(input),
+		nil,
+		0,
+		emailvalidator.WithBulkCanonicalizer(emailvalidator.ProviderKey),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string][]string{"jdoe@gmail.com": {"j.doe+x@gmail.com", "jdoe@gmail.com", "JDoe@googlemail.com"}}
+	if summary.Duplicates != 2 || !reflect.DeepEqual(summary.DuplicateGroups, want) {
+		t.Errorf("unexpected duplicates %d: %v", summary.Duplicates, summary.DuplicateGroups)
+	}
+}
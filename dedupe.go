@@ -0,0 +1,166 @@
+package emailvalidator
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// SeenSet records which keys a Deduplicator has already emitted.  Implementations backed by probabilistic structures
+// (e.g. bloom filters) or external stores may be used for lists too large to hold in memory.
+type SeenSet interface {
+	// Seen marks key as seen, returning true if it had already been seen.
+	Seen(key string) bool
+}
+
+// MemorySeenSet is a SeenSet backed by a map.  It is safe for concurrent use.
+type MemorySeenSet struct {
+	mu   sync.Mutex
+	keys map[string]struct{}
+}
+
+func NewMemorySeenSet() *MemorySeenSet {
+	return &MemorySeenSet{keys: make(map[string]struct{})}
+}
+
+func (s *MemorySeenSet) Seen(key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.keys[key]; ok {
+		return true
+	}
+	s.keys[key] = struct{}{}
+	return false
+}
+
+// Len returns the number of unique keys seen
+func (s *MemorySeenSet) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.keys)
+}
+
+type DedupeOptions struct {
+	// ParseOptions are used when parsing each address prior to normalization
+	ParseOptions []OptFunc
+
+	// SeenSet is used to track previously emitted addresses.  Defaults to a MemorySeenSet.
+	SeenSet SeenSet
+
+	// Canonicalize, if defined, is used in place of NormalizeKey to compute the deduplication key of each
+	// successfully parsed address.  Use this to collapse provider-specific variants such as sub-addresses.
+	Canonicalize func(Result) string
+}
+
+type DedupeOptFunc func(*DedupeOptions)
+
+func WithSeenSet(set SeenSet) DedupeOptFunc {
+	return func(opt *DedupeOptions) {
+		opt.SeenSet = set
+	}
+}
+
+func WithCanonicalizer(fn func(Result) string) DedupeOptFunc {
+	return func(opt *DedupeOptions) {
+		opt.Canonicalize = fn
+	}
+}
+
+func WithDedupeParseOptions(opts ...OptFunc) DedupeOptFunc {
+	return func(opt *DedupeOptions) {
+		opt.ParseOptions = append(opt.ParseOptions, opts...)
+	}
+}
+
+// NormalizeKey returns the minimal form of the local part and a lower-cased domain.  The local part's case is left
+// as-is, as it is case-sensitive per RFC 5321.
+func NormalizeKey(res Result) string {
+	return fmt.Sprintf("%s@%s", minimalLocal(res.Local, res.Quoted), strings.ToLower(res.Domain))
+}
+
+// LowercaseKey returns NormalizeKey with the local part lowercased as well.  Use it via WithCanonicalizer to treat
+// addresses differing only in the case of their local part as duplicates, as nearly all receivers do.
+func LowercaseKey(res Result) string {
+	return strings.ToLower(NormalizeKey(res))
+}
+
+// dotInsensitiveDomains maps domains known to ignore periods within local parts to their canonical domain
+var dotInsensitiveDomains = map[string]string{
+	"gmail.com":      "gmail.com",
+	"googlemail.com": "gmail.com",
+}
+
+// ProviderKey returns LowercaseKey with any "+" sub-address removed, and periods removed from the local parts of
+// providers known to ignore them, e.g. "j.doe+x@gmail.com" and "jdoe@googlemail.com" share the key "jdoe@gmail.com".
+// Quoted local parts are left intact.
+func ProviderKey(res Result) string {
+	if res.Quoted {
+		return LowercaseKey(res)
+	}
+	local, domain := strings.ToLower(res.Local), strings.ToLower(res.Domain)
+	if idx := strings.IndexByte(local, '+'); idx > 0 {
+		local = local[:idx]
+	}
+	if canonical, ok := dotInsensitiveDomains[domain]; ok {
+		local, domain = strings.ReplaceAll(local, ".", ""), canonical
+	}
+	return local + "@" + domain
+}
+
+// Deduplicator emits only the first occurrence of each address, as determined by its normalized or canonicalized form
+type Deduplicator struct {
+	opts DedupeOptions
+}
+
+func NewDeduplicator(opts ...DedupeOptFunc) *Deduplicator {
+	d := new(Deduplicator)
+	for _, fn := range opts {
+		fn(&d.opts)
+	}
+	if d.opts.SeenSet == nil {
+		d.opts.SeenSet = NewMemorySeenSet()
+	}
+	if d.opts.Canonicalize == nil {
+		d.opts.Canonicalize = NormalizeKey
+	}
+	return d
+}
+
+// Key returns the deduplication key for email.  Addresses that fail to parse are keyed by their trimmed input.
+func (d *Deduplicator) Key(email string) string {
+	res, err := BuildResult(email, d.opts.ParseOptions...)
+	if err != nil {
+		return strings.TrimSpace(email)
+	}
+	return d.opts.Canonicalize(res)
+}
+
+// Keep returns true if this is the first time email's key has been seen
+func (d *Deduplicator) Keep(email string) bool {
+	return !d.opts.SeenSet.Seen(d.Key(email))
+}
+
+// Filter reads newline-delimited addresses from r, writing only first occurrences to w.  Lines longer than
+// MaxLineLength cannot contain an address, and are dropped.
+func (d *Deduplicator) Filter(r io.Reader, w io.Writer) error {
+	br := bufio.NewReader(r)
+	for {
+		line, err := readLine(br)
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if errors.Is(err, ErrLineTooLong) {
+			continue
+		} else if err != nil {
+			return fmt.Errorf("error reading addresses: %w", err)
+		}
+		if !d.Keep(line) {
+			continue
+		}
+		if _, err = fmt.Fprintln(w, line); err != nil {
+			return fmt.Errorf("error writing address: %w", err)
+		}
+	}
+}
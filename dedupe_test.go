@@ -0,0 +1,95 @@
+package emailvalidator_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	emailvalidator "github.com/dcarbone/go-email-validator"
+)
+
+func TestDeduplicator_Filter(t *testing.T) {
+	const input = `simple@example.com
+simple@EXAMPLE.com
+Simple@example.com
+(comment)simple@example.com
+"simple"@example.com
+nope
+nope
+`
+
+	out := new(bytes.Buffer)
+	if err := emailvalidator.NewDeduplicator().Filter(strings.NewReader(input), out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	const expected = `simple@example.com
+Simple@example.com
+nope
+`
+	if out.String() != expected {
+		t.Errorf("expected:\n%s\nsaw:\n%s", expected, out.String())
+	}
+}
+
+func TestDeduplicator_Canonicalize(t *testing.T) {
+	dedupe := emailvalidator.NewDeduplicator(
+		emailvalidator.WithCanonicalizer(func(res emailvalidator.Result) string {
+			local, _, _ := strings.Cut(res.Local, "+")
+			return strings.ToLower(local + "@" + res.Domain)
+		}),
+	)
+
+	if !dedupe.Keep("user+one@example.com") {
+		t.Error("expected first occurrence to be kept")
+	}
+	if dedupe.Keep("USER+two@example.com") {
+		t.Error("expected canonical duplicate to be dropped")
+	}
+}
+
+func TestDeduplicator_LowercaseKey(t *testing.T) {
+	dedupe := emailvalidator.NewDeduplicator(emailvalidator.WithCanonicalizer(emailvalidator.LowercaseKey))
+
+	if !dedupe.Keep("Jane.Doe@example.com") {
+		t.Error("expected first occurrence to be kept")
+	}
+	if dedupe.Keep(`"jane.doe"@EXAMPLE.com`) {
+		t.Error("expected case variant to be dropped")
+	}
+}
+
+func TestProviderKey(t *testing.T) {
+	tests := []struct {
+		email string
+		want  string
+	}{
+		{"j.doe+x@gmail.com", "jdoe@gmail.com"},
+		{"JDoe@GoogleMail.com", "jdoe@gmail.com"},
+		{"j.doe+x@example.com", "j.doe@example.com"},
+		{"\"j.doe+x\"@gmail.com", "j.doe+x@gmail.com"},
+	}
+	for _, tt := range tests {
+		res, err := emailvalidator.BuildResult(tt.email)
+		if err != nil {
+			t.Fatalf("unexpected error parsing %q: %v", tt.email, err)
+		}
+		if got := emailvalidator.ProviderKey(res); got != tt.want {
+			t.Errorf("ProviderKey(%q): expected %q, saw %q", tt.email, tt.want, got)
+		}
+	}
+}
+
+func TestDeduplicator_FilterLineTooLong(t *testing.T) {
+	input := "simple@example.com\n" + strings.Repeat("x", emailvalidator.MaxLineLength+1) + "\nsimple@example.com\nnope"
+
+	out := new(bytes.Buffer)
+	if err := emailvalidator.NewDeduplicator().Filter(strings.NewReader(input), out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	const expected = "simple@example.com\nnope\n"
+	if out.String() != expected {
+		t.Errorf("expected:\n%s\nsaw:\n%s", expected, out.String())
+	}
+}
@@ -0,0 +1,50 @@
+package emailvalidator
+
+import (
+	"reflect"
+)
+
+// FieldDiff describes a single Result field whose value differs between two results
+type FieldDiff struct {
+	// Field is the name of the Result field, e.g. "Domain"
+	Field string
+
+	// A and B contain the field's value within each result
+	A, B any
+}
+
+// Diff reports the fields that differ between a and b, in Result field order.  This is useful when auditing the impact
+// of a configuration change, e.g. parsing a corpus under two behavior versions or profiles.  Err fields are compared
+// by message.
+func Diff(a, b Result) []FieldDiff {
+	var (
+		out []FieldDiff
+		av  = reflect.ValueOf(a)
+		bv  = reflect.ValueOf(b)
+		typ = av.Type()
+	)
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.Name == "Err" {
+			continue
+		}
+		af, bf := av.Field(i).Interface(), bv.Field(i).Interface()
+		if !reflect.DeepEqual(af, bf) {
+			out = append(out, FieldDiff{Field: field.Name, A: af, B: bf})
+		}
+	}
+
+	if errString(a.Err) != errString(b.Err) {
+		out = append(out, FieldDiff{Field: "Err", A: a.Err, B: b.Err})
+	}
+
+	return out
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
@@ -0,0 +1,45 @@
+package emailvalidator_test
+
+import (
+	"testing"
+
+	emailvalidator "github.com/dcarbone/go-email-validator"
+)
+
+func TestDiff(t *testing.T) {
+	a, _ := emailvalidator.BuildResult("user@example.com")
+	if diffs := emailvalidator.Diff(a, a); len(diffs) != 0 {
+		t.Errorf("expected no diffs, saw %+v", diffs)
+	}
+
+	b, _ := emailvalidator.BuildResult("(work)user@example.org")
+	diffs := emailvalidator.Diff(a, b)
+	var fields []string
+	for _, d := range diffs {
+		fields = append(fields, d.Field)
+	}
+	expected := []string{"Input", "Domain", "DomainPosition", "Comment", "Comments", "Stripped", "Flags"}
+	if len(fields) != len(expected) {
+		t.Fatalf("expected fields %v, saw %v", expected, fields)
+	}
+	for i := range expected {
+		if fields[i] != expected[i] {
+			t.Errorf("expected fields %v, saw %v", expected, fields)
+			break
+		}
+	}
+	if diffs[1].A != "example.com" || diffs[1].B != "example.org" {
+		t.Errorf("unexpected Domain diff: %+v", diffs[1])
+	}
+}
+
+func TestDiff_BehaviorVersions(t *testing.T) {
+	const input = "user@example.com."
+	a, _ := emailvalidator.BuildResult(input)
+	b, _ := emailvalidator.BuildResult(input, emailvalidator.WithBehaviorVersion(emailvalidator.BehaviorVersion2))
+
+	diffs := emailvalidator.Diff(a, b)
+	if len(diffs) != 1 || diffs[0].Field != "Err" || diffs[0].A != nil || diffs[0].B == nil {
+		t.Errorf("expected only Err to differ, saw %+v", diffs)
+	}
+}
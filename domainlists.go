@@ -0,0 +1,150 @@
+package emailvalidator
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DomainLists holds named lists of domains, e.g. "disposable", "free", or "vip", used to tag parsed addresses.  Lists
+// may be replaced at any time, and DomainLists is safe for concurrent use.
+type DomainLists struct {
+	mu    sync.RWMutex
+	lists map[string]map[string]struct{}
+}
+
+func NewDomainLists() *DomainLists {
+	return &DomainLists{lists: make(map[string]map[string]struct{})}
+}
+
+// Set replaces the list named tag with domains
+func (d *DomainLists) Set(tag string, domains []string) {
+	list := make(map[string]struct{}, len(domains))
+	for _, domain := range domains {
+		list[strings.ToLower(domain)] = struct{}{}
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.lists[tag] = list
+}
+
+// Load replaces the list named tag with the domains read from r, one per line.  Blank lines and lines beginning with
+// "#" are skipped.  If reading fails, the existing list is left untouched.
+func (d *DomainLists) Load(tag string, r io.Reader) error {
+	var (
+		domains []string
+		scanner = bufio.NewScanner(r)
+	)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		domains = append(domains, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("error reading %q domain list: %w", tag, err)
+	}
+	d.Set(tag, domains)
+	return nil
+}
+
+// LoadFile calls Load with the contents of the file at path
+func (d *DomainLists) LoadFile(tag, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("error opening %q domain list: %w", tag, err)
+	}
+	defer f.Close()
+	return d.Load(tag, f)
+}
+
+// LoadFS calls Load with the contents of the file name within fsys, e.g. an embed.FS bundled into the binary
+func (d *DomainLists) LoadFS(tag string, fsys fs.FS, name string) error {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return fmt.Errorf("error opening %q domain list: %w", tag, err)
+	}
+	defer f.Close()
+	return d.Load(tag, f)
+}
+
+// WatchFile loads the list named tag from the file at path, then polls the file every interval, reloading it whenever
+// its modification time or size changes.  Errors seen while reloading are passed to onError, if defined, and the
+// previously loaded list is kept.  WatchFile blocks until ctx is done, and only returns an error if the initial load
+// fails.
+func (d *DomainLists) WatchFile(ctx context.Context, tag, path string, interval time.Duration, onError func(error)) error {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("error opening %q domain list: %w", tag, err)
+	}
+	if err = d.LoadFile(tag, path); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		next, err := os.Stat(path)
+		if err == nil {
+			if next.ModTime().Equal(fi.ModTime()) && next.Size() == fi.Size() {
+				continue
+			}
+			if err = d.LoadFile(tag, path); err == nil {
+				fi = next
+				continue
+			}
+		}
+		if onError != nil {
+			onError(err)
+		}
+	}
+}
+
+// Tags returns the sorted names of every list containing domain or one of its parent domains, e.g. a list containing
+// "example.com" matches "mail.example.com".
+func (d *DomainLists) Tags(domain string) []string {
+	domain = strings.ToLower(domain)
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	var tags []string
+	for tag, list := range d.lists {
+		for candidate := domain; candidate != ""; {
+			if _, ok := list[candidate]; ok {
+				tags = append(tags, tag)
+				break
+			}
+			idx := strings.IndexByte(candidate, '.')
+			if idx < 0 {
+				break
+			}
+			candidate = candidate[idx+1:]
+		}
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// WithDomainLists sets the DomainLists used to populate Result.DomainTags
+func WithDomainLists(lists *DomainLists) OptFunc {
+	return func(opt *ParseOptions) {
+		opt.DomainLists = lists
+	}
+}
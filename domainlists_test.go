@@ -0,0 +1,102 @@
+package emailvalidator_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	emailvalidator "github.com/dcarbone/go-email-validator"
+)
+
+func TestDomainLists(t *testing.T) {
+	lists := emailvalidator.NewDomainLists()
+	lists.Set("free", []string{"gmail.com", "Yahoo.com"})
+	if err := lists.Load("disposable", strings.NewReader("# disposable providers\n\nmailinator.com\nyahoo.com\n")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	steps := []struct {
+		input string
+		tags  []string
+	}{
+		{"user@gmail.com", []string{"free"}},
+		{"user@YAHOO.com", []string{"disposable", "free"}},
+		{"user@eu.mailinator.com", []string{"disposable"}},
+		{"user@notmailinator.com", nil},
+		{"user@[127.0.0.1]", nil},
+	}
+	for _, step := range steps {
+		res, _ := emailvalidator.BuildResult(step.input, emailvalidator.WithDomainLists(lists))
+		if !reflect.DeepEqual(res.DomainTags, step.tags) {
+			t.Errorf("%s: expected tags %v, saw %v", step.input, step.tags, res.DomainTags)
+		}
+	}
+}
+
+func TestDomainLists_WatchFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "blocked.txt")
+	if err := os.WriteFile(path, []byte("example.com\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	lists := emailvalidator.NewDomainLists()
+	done := make(chan error)
+	go func() {
+		done <- lists.WatchFile(ctx, "blocked", path, 5*time.Millisecond, nil)
+	}()
+
+	waitForTags := func(domain string, expected []string) {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for !reflect.DeepEqual(lists.Tags(domain), expected) {
+			if time.Now().After(deadline) {
+				t.Fatalf("expected %s to have tags %v, saw %v", domain, expected, lists.Tags(domain))
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}
+
+	waitForTags("example.com", []string{"blocked"})
+
+	if err := os.WriteFile(path, []byte("example.org\nexample.net\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	waitForTags("example.org", []string{"blocked"})
+	waitForTags("example.com", nil)
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestDomainLists_WatchFileMissing(t *testing.T) {
+	err := emailvalidator.NewDomainLists().
+		WatchFile(context.Background(), "blocked", filepath.Join(t.TempDir(), "missing"), time.Millisecond, nil)
+	if err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestDomainLists_LoadFS(t *testing.T) {
+	fsys := fstest.MapFS{"lists/disposable.txt": {Data: []byte("# bundled\nmailinator.com\n")}}
+
+	lists := emailvalidator.NewDomainLists()
+	if err := lists.LoadFS("disposable", fsys, "lists/disposable.txt"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tags := lists.Tags("mailinator.com"); !reflect.DeepEqual(tags, []string{"disposable"}) {
+		t.Errorf("unexpected tags %v", tags)
+	}
+	if err := lists.LoadFS("free", fsys, "lists/free.txt"); err == nil {
+		t.Error("expected error loading missing file")
+	}
+}
@@ -0,0 +1,125 @@
+package emailvalidator
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"regexp"
+	"strings"
+)
+
+var (
+	ErrEngineRejected = errors.New("address rejected by syntax engine")
+)
+
+// SyntaxValidator is a syntax engine capable of parsing a single address.  The package's own state machine is the
+// default engine; alternates may be selected with WithEngine, e.g. to compare engines within the same pipeline.
+type SyntaxValidator interface {
+	Parse(email string, opts ParseOptions) (Result, error)
+}
+
+// WithEngine sets the SyntaxValidator used to parse addresses
+func WithEngine(engine SyntaxValidator) OptFunc {
+	return func(opt *ParseOptions) {
+		opt.Engine = engine
+	}
+}
+
+// StateMachineEngine is the package's default, RFC-oriented syntax engine
+type StateMachineEngine struct{}
+
+func (StateMachineEngine) Parse(email string, opts ParseOptions) (Result, error) {
+	opts.Engine = nil
+	return BuildResult(email, WithOptions(opts))
+}
+
+// html5Pattern is the "valid email address" pattern from the WHATWG HTML standard
+var html5Pattern = regexp.MustCompile("^[a-zA-Z0-9.!#$%&'*+/=?^_`{|}~-]+@[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(?:\\.[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$")
+
+// HTML5Engine accepts exactly the addresses browsers accept in an <input type="email"> field.  It populates only the
+// Input, Local, Mailbox, Domain, and Stripped fields of the Result, and ignores all options other than those used to
+// localize messages.
+type HTML5Engine struct{}
+
+func (HTML5Engine) Parse(email string, opts ParseOptions) (Result, error) {
+	if !html5Pattern.MatchString(email) {
+		return engineRejected(email, "does not match html5 email pattern", &opts)
+	}
+	return engineResult(email, email), nil
+}
+
+// NetMailEngine delegates to net/mail.ParseAddress, rejecting any input containing a display name or angle brackets.  It populates
+// only the Input, Local, Mailbox, Domain, and Stripped fields of the Result, and ignores all options other than those
+// used to localize messages.
+type NetMailEngine struct{}
+
+func (NetMailEngine) Parse(email string, opts ParseOptions) (Result, error) {
+	addr, err := mail.ParseAddress(email)
+	if err != nil {
+		return engineRejected(email, err.Error(), &opts)
+	}
+	// net/mail reports comments as the display name, so addr.Name alone cannot tell the two apart
+	if hasAngleAddr(email) {
+		return engineRejected(email, "display names are not allowed", &opts)
+	}
+	return engineResult(email, addr.Address), nil
+}
+
+// hasAngleAddr returns true if email contains a "<" outside of any quoted string or comment, i.e. if it is written as
+// a name-addr or angle-addr rather than a bare addr-spec, wherever any trailing whitespace or comments fall
+func hasAngleAddr(email string) bool {
+	var (
+		inQuote bool
+		depth   int
+	)
+	for i := 0; i < len(email); i++ {
+		switch c := email[i]; {
+		case c == '\\' && (inQuote || depth > 0):
+			i++
+		case inQuote:
+			inQuote = c != '"'
+		case c == '(':
+			depth++
+		case c == ')' && depth > 0:
+			depth--
+		case depth > 0:
+		case c == '"':
+			inQuote = true
+		case c == '<':
+			return true
+		}
+	}
+	return false
+}
+
+// withPreParse carries the state gathered by BuildResult before delegating to an engine into the engine's Result:
+// the original input, the decoding and repairs applied to it, and any warnings raised along the way
+func withPreParse(res, pre Result) Result {
+	res.Input = pre.Input
+	res.PercentEncoded = res.PercentEncoded || pre.PercentEncoded
+	res.HTMLEncoded = res.HTMLEncoded || pre.HTMLEncoded
+	res.Fixes = append(append([]Fix(nil), pre.Fixes...), res.Fixes...)
+	res.Warnings = append(append([]ParseError(nil), pre.Warnings...), res.Warnings...)
+	res.Flags |= flagsOf(&res)
+	return res
+}
+
+// engineResult builds the Result of an alternate engine from a validated address
+func engineResult(email, address string) Result {
+	idx := strings.LastIndexByte(address, '@')
+	res := Result{
+		Input:    email,
+		Local:    address[:idx],
+		Mailbox:  address[:idx],
+		Domain:   address[idx+1:],
+		Stripped: address,
+	}
+	res.Quoted = strings.HasPrefix(res.Local, `"`)
+	return res
+}
+
+// engineRejected builds the Result and error of an alternate engine for a rejected address
+func engineRejected(email, reason string, opts *ParseOptions) (Result, error) {
+	err := localize(newParseError(fmt.Errorf("%w: %s", ErrEngineRejected, reason), -1, "", ""), opts)
+	return Result{Input: email, Err: err}, err
+}
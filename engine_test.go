@@ -0,0 +1,125 @@
+package emailvalidator_test
+
+import (
+	"errors"
+	"testing"
+
+	emailvalidator "github.com/dcarbone/go-email-validator"
+)
+
+func TestEngines(t *testing.T) {
+	steps := []struct {
+		input   string
+		machine bool
+		html5   bool
+		netMail bool
+	}{
+		{input: "simple@example.com", machine: true, html5: true, netMail: true},
+		{input: `" "@example.org`, machine: true, netMail: true},
+		{input: "postmaster@[123.123.123.123]", machine: true, netMail: true},
+		{input: "user@example.com (work)", machine: true, netMail: true},
+		{input: "Jane <jane@example.com>"},
+		{input: "Jane <jane@example.com>  "},
+		{input: "Jane <jane@example.com> (work)"},
+		{input: "<a@b.example> (c)"},
+		{input: `"<a>"@example.com`, machine: true, netMail: true},
+		{input: "user@example.com (<work>)", netMail: true},
+		{input: "a@b@example.com"},
+		{input: "i.like.underscores@but_they_are_not_allowed_in_this_part", netMail: true},
+	}
+
+	engines := []struct {
+		name   string
+		engine emailvalidator.SyntaxValidator
+		valid  func(int) bool
+	}{
+		{"machine", emailvalidator.StateMachineEngine{}, func(i int) bool { return steps[i].machine }},
+		{"html5", emailvalidator.HTML5Engine{}, func(i int) bool { return steps[i].html5 }},
+		{"net/mail", emailvalidator.NetMailEngine{}, func(i int) bool { return steps[i].netMail }},
+	}
+
+	v := emailvalidator.NewValidator()
+	for _, e := range engines {
+		for i, step := range steps {
+			res, err := v.Parse(step.input, emailvalidator.WithEngine(e.engine))
+			if e.valid(i) {
+				if err != nil {
+					t.Errorf("%s: %q: unexpected error: %v", e.name, step.input, err)
+				} else if res.Domain == "" || res.Local == "" {
+					t.Errorf("%s: %q: unexpected result: %+v", e.name, step.input, res)
+				}
+			} else if err == nil {
+				t.Errorf("%s: %q: expected error", e.name, step.input)
+			}
+		}
+	}
+}
+
+func TestEngines_Rejected(t *testing.T) {
+	_, err := emailvalidator.BuildResult(`" "@example.org`, emailvalidator.WithEngine(emailvalidator.HTML5Engine{}))
+	if !errors.Is(err, emailvalidator.ErrEngineRejected) {
+		t.Fatalf("expected ErrEngineRejected, saw %v", err)
+	}
+	if pes := emailvalidator.ErrorsOf(err); len(pes) != 1 || pes[0].Message == "" {
+		t.Errorf("expected a single localized ParseError, saw %+v", pes)
+	}
+}
+
+type lowercaseEngine struct{}
+
+func (lowercaseEngine) Parse(email string, opts emailvalidator.ParseOptions) (emailvalidator.Result, error) {
+	res, err := emailvalidator.StateMachineEngine{}.Parse(email, opts)
+	res.Domain = "engine:" + res.Domain
+	return res, err
+}
+
+func TestWithEngine_Custom(t *testing.T) {
+	v := emailvalidator.NewValidator(emailvalidator.WithEngine(lowercaseEngine{}))
+	res, err := v.Parse("user@example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Domain != "engine:example.com" {
+		t.Errorf("expected custom engine to be used, saw %q", res.Domain)
+	}
+}
+
+func TestWithEngine_PreParse(t *testing.T) {
+	steps := []struct {
+		label    string
+		input    string
+		opt      emailvalidator.OptFunc
+		stripped string
+		warning  error
+		fixes    int
+		flag     emailvalidator.Flag
+	}{
+		{label: "percent", input: "o%2540neil%40example.com", opt: emailvalidator.DecodePercent, stripped: "o%40neil@example.com", warning: emailvalidator.ErrPercentDecoded, flag: emailvalidator.FlagPercentEncoded},
+		{label: "html", input: "oneil&#64;example.com", opt: emailvalidator.DecodeHTMLEntities, stripped: "oneil@example.com", warning: emailvalidator.ErrHTMLDecoded, flag: emailvalidator.FlagHTMLEncoded},
+		{label: "punctuation", input: "o\u2019neil@example.com", opt: emailvalidator.RepairPunctuation, stripped: "o'neil@example.com", fixes: 1},
+		{label: "invisible", input: "oneil\u200b@example.com", opt: emailvalidator.StripInvisible, stripped: "oneil@example.com", warning: emailvalidator.ErrInvisibleCharacter},
+	}
+
+	engines := []emailvalidator.SyntaxValidator{emailvalidator.StateMachineEngine{}, emailvalidator.HTML5Engine{}}
+	for _, step := range steps {
+		for _, engine := range engines {
+			res, err := emailvalidator.BuildResult(step.input, step.opt, emailvalidator.WithEngine(engine))
+			if err != nil {
+				t.Errorf("%s: %T: unexpected error: %v", step.label, engine, err)
+				continue
+			}
+			if res.Input != step.input || res.Stripped != step.stripped {
+				t.Errorf("%s: %T: expected input %q and stripped %q, saw %q and %q", step.label, engine, step.input, step.stripped, res.Input, res.Stripped)
+			}
+			if len(res.Fixes) != step.fixes {
+				t.Errorf("%s: %T: expected %d fixes, saw %v", step.label, engine, step.fixes, res.Fixes)
+			}
+			if step.warning != nil && (len(res.Warnings) != 1 || !errors.Is(res.Warnings[0], step.warning)) {
+				t.Errorf("%s: %T: expected %v warning, saw %v", step.label, engine, step.warning, res.Warnings)
+			}
+			if step.flag != 0 && !res.Flags.Has(step.flag) {
+				t.Errorf("%s: %T: expected flag %v, saw %v", step.label, engine, step.flag, res.Flags)
+			}
+		}
+	}
+}
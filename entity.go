@@ -0,0 +1,46 @@
+package emailvalidator
+
+import (
+	"errors"
+	"html"
+)
+
+var (
+	ErrHTMLDecoded = errors.New("html entities decoded")
+)
+
+// hasHTMLEntity returns true if s contains a semicolon-terminated HTML character reference, e.g. "&#64;", "&#x40;",
+// or "&amp;", as is the case with addresses scraped from, or pasted out of, HTML source.  "&" is valid within a local
+// part, so this is only a signal.
+func hasHTMLEntity(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] != '&' {
+			continue
+		}
+		j := i + 1
+		if j < len(s) && s[j] == '#' {
+			j++
+			if j < len(s) && (s[j] == 'x' || s[j] == 'X') {
+				j++
+			}
+		}
+		start := j
+		for j < len(s) && (isHexDigit(s[j]) || (s[j]|0x20 >= 'a' && s[j]|0x20 <= 'z')) {
+			j++
+		}
+		if j > start && j < len(s) && s[j] == ';' && html.UnescapeString(s[i:j+1]) != s[i:j+1] {
+			return true
+		}
+	}
+	return false
+}
+
+// decodeHTMLEntities returns email with HTML character references decoded, and an ErrHTMLDecoded warning, if email
+// contains any.  Otherwise, email is returned as-is.
+func decodeHTMLEntities(email string) (string, *ParseError) {
+	if !hasHTMLEntity(email) {
+		return email, nil
+	}
+	pe := newParseError(ErrHTMLDecoded, -1, "", "")
+	return html.UnescapeString(email), &pe
+}
@@ -0,0 +1,75 @@
+package emailvalidator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// EnvelopeSchemaVersion is the version of the ValidationRequest and ValidationResponse JSON schemas.  It follows the
+// same rules as ReportSchemaVersion.
+const EnvelopeSchemaVersion = 1
+
+var (
+	ErrInvalidEnvelope = errors.New("invalid envelope")
+)
+
+// ValidationRequest is a batch of addresses submitted for validation via a message queue
+type ValidationRequest struct {
+	SchemaVersion int `json:"schema_version"`
+
+	// CorrelationID is an opaque identifier chosen by the producer, and is copied to the ValidationResponse
+	CorrelationID string `json:"correlation_id"`
+
+	Emails []string `json:"emails"`
+}
+
+// ValidationResponse is the outcome of a ValidationRequest
+type ValidationResponse struct {
+	SchemaVersion int    `json:"schema_version"`
+	CorrelationID string `json:"correlation_id"`
+
+	// Reports contains one Report per requested address, in request order
+	Reports []Report `json:"reports"`
+}
+
+// Process validates each address within req with v.  If ctx is done before every address is validated, the context's
+// error is returned alongside the Reports produced so far.
+func (req ValidationRequest) Process(ctx context.Context, v EmailValidator) (ValidationResponse, error) {
+	resp := ValidationResponse{
+		SchemaVersion: EnvelopeSchemaVersion,
+		CorrelationID: req.CorrelationID,
+		Reports:       make([]Report, 0, len(req.Emails)),
+	}
+	for _, email := range req.Emails {
+		if err := ctx.Err(); err != nil {
+			return resp, err
+		}
+		start := time.Now()
+		res, err := v.Validate(ctx, email)
+		resp.Reports = append(resp.Reports, NewReport(res, err, time.Since(start)))
+	}
+	return resp, nil
+}
+
+// HandleEnvelope decodes msg as a JSON-encoded ValidationRequest, processes it with v, and returns the JSON-encoded
+// ValidationResponse.  It is intended to be called by queue consumers once per message.  Messages that cannot be
+// decoded, or that declare a newer schema version, produce an error wrapping ErrInvalidEnvelope, and are typically
+// dead-lettered.
+func HandleEnvelope(ctx context.Context, v EmailValidator, msg []byte) ([]byte, error) {
+	var req ValidationRequest
+	if err := json.Unmarshal(msg, &req); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidEnvelope, err)
+	}
+	if req.SchemaVersion > EnvelopeSchemaVersion {
+		return nil, fmt.Errorf("%w: unsupported schema version %d", ErrInvalidEnvelope, req.SchemaVersion)
+	}
+
+	resp, err := req.Process(ctx, v)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(resp)
+}
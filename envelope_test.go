@@ -0,0 +1,66 @@
+package emailvalidator_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	emailvalidator "github.com/dcarbone/go-email-validator"
+)
+
+func TestHandleEnvelope(t *testing.T) {
+	v := emailvalidator.NewValidator()
+
+	out, err := emailvalidator.HandleEnvelope(context.Background(), v,
+		[]byte(`{"schema_version":1,"correlation_id":"abc","emails":["jane@example.com","jane@@example.com"]}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var resp emailvalidator.ValidationResponse
+	if err = json.Unmarshal(out, &resp); err != nil {
+		t.Fatalf("error decoding response: %v", err)
+	}
+	if resp.SchemaVersion != emailvalidator.EnvelopeSchemaVersion || resp.CorrelationID != "abc" || len(resp.Reports) != 2 {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+	if resp.Reports[0].Verdict != emailvalidator.VerdictValid || resp.Reports[1].Verdict != emailvalidator.VerdictInvalid {
+		t.Errorf("unexpected verdicts: %q, %q", resp.Reports[0].Verdict, resp.Reports[1].Verdict)
+	}
+
+	for _, msg := range []string{`{"emails":`, `{"schema_version":2,"emails":[]}`} {
+		if _, err = emailvalidator.HandleEnvelope(context.Background(), v, []byte(msg)); !errors.Is(err, emailvalidator.ErrInvalidEnvelope) {
+			t.Errorf("expected ErrInvalidEnvelope for %s, saw %v", msg, err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err = emailvalidator.HandleEnvelope(ctx, v, []byte(`{"emails":["jane@example.com"]}`)); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, saw %v", err)
+	}
+}
+
+// A consumer worker, with a channel standing in for a message queue subscription.  Replies are published with the
+// request's correlation ID; undecodable messages would be dead-lettered.
+func ExampleHandleEnvelope() {
+	queue := make(chan []byte, 1)
+	queue <- []byte(`{"schema_version":1,"correlation_id":"signup-42","emails":["jane@example.com"]}`)
+	close(queue)
+
+	v := emailvalidator.NewValidator()
+	for msg := range queue {
+		reply, err := emailvalidator.HandleEnvelope(context.Background(), v, msg)
+		if err != nil {
+			fmt.Println("dead-letter:", err)
+			continue
+		}
+
+		var resp emailvalidator.ValidationResponse
+		_ = json.Unmarshal(reply, &resp)
+		fmt.Println(resp.CorrelationID, resp.Reports[0].Verdict)
+	}
+	// Output: signup-42 valid
+}
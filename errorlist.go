@@ -0,0 +1,166 @@
+package emailvalidator
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// ErrorList is the flattened list of errors contained within an error returned by this package.  It serializes to a
+// JSON array of diagnostics, and deserializes back into ParseErrors that continue to satisfy errors.Is against this
+// package's sentinels.
+type ErrorList []error
+
+// ErrorListOf flattens err into an ErrorList.  A nil err produces a nil list.
+func ErrorListOf(err error) ErrorList {
+	return flattenErrors(err)
+}
+
+func (l ErrorList) Error() string {
+	msgs := make([]string, len(l))
+	for i, err := range l {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+func (l ErrorList) Unwrap() []error {
+	return l
+}
+
+// Err returns l as an error, or nil if l is empty
+func (l ErrorList) Err() error {
+	if len(l) == 0 {
+		return nil
+	}
+	return l
+}
+
+// jsonDiagnostic is the serialized form of a single error within an ErrorList
+type jsonDiagnostic struct {
+	Code     ErrorCode `json:"code"`
+	Message  string    `json:"message,omitempty"`
+	Detail   string    `json:"detail"`
+	Position int       `json:"position"`
+	Char     string    `json:"char,omitempty"`
+	Segment  Segment   `json:"segment,omitempty"`
+}
+
+func (l ErrorList) MarshalJSON() ([]byte, error) {
+	out := make([]jsonDiagnostic, len(l))
+	for i, err := range l {
+		var pe ParseError
+		if errors.As(err, &pe) {
+			out[i] = jsonDiagnostic{
+				Code:     pe.Code,
+				Message:  pe.Message,
+				Detail:   pe.Error(),
+				Position: pe.Position,
+				Char:     pe.Char,
+				Segment:  pe.Segment,
+			}
+		} else {
+			out[i] = jsonDiagnostic{
+				Code:     CodeOf(err),
+				Detail:   err.Error(),
+				Position: -1,
+			}
+		}
+	}
+	return json.Marshal(out)
+}
+
+func (l *ErrorList) UnmarshalJSON(b []byte) error {
+	var in []jsonDiagnostic
+	if err := json.Unmarshal(b, &in); err != nil {
+		return err
+	}
+	*l = make(ErrorList, len(in))
+	for i, d := range in {
+		(*l)[i] = ParseError{
+			Code:     d.Code,
+			Position: d.Position,
+			Char:     d.Char,
+			Segment:  d.Segment,
+			Message:  d.Message,
+			Err:      decodedError{msg: d.Detail, sentinel: d.Code.Sentinel()},
+		}
+	}
+	return nil
+}
+
+// decodedError is an error reconstructed from its serialized form
+type decodedError struct {
+	msg      string
+	sentinel error
+}
+
+func (e decodedError) Error() string {
+	return e.msg
+}
+
+func (e decodedError) Unwrap() error {
+	return e.sentinel
+}
+
+// StatusCodeOf maps err to the HTTP status code most appropriate for reporting it to an API client
+func StatusCodeOf(err error) int {
+	switch CodeOf(err) {
+	case CodeRemoteValidator, CodeSinkDelivery:
+		return http.StatusBadGateway
+	case CodeCSVColumnMissing, CodeInvalidEnvelope, CodeLineTooLong:
+		return http.StatusBadRequest
+	case CodeUnknown:
+		if err == nil {
+			return http.StatusOK
+		}
+		return http.StatusInternalServerError
+	default:
+		return http.StatusUnprocessableEntity
+	}
+}
+
+// ProblemDetails is an RFC 7807 problem details document describing a validation failure
+type ProblemDetails struct {
+	Type     string    `json:"type"`
+	Title    string    `json:"title"`
+	Status   int       `json:"status"`
+	Detail   string    `json:"detail,omitempty"`
+	Instance string    `json:"instance,omitempty"`
+	Errors   ErrorList `json:"errors,omitempty"`
+
+	// InvalidFields contains the errors seen in each invalid request field, by field name, when written by
+	// ValidateFields
+	InvalidFields map[string]ErrorList `json:"invalid_fields,omitempty"`
+}
+
+// NewProblemDetails builds a ProblemDetails document from err
+func NewProblemDetails(err error) ProblemDetails {
+	status := StatusCodeOf(err)
+	pd := ProblemDetails{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Errors: ErrorListOf(err),
+	}
+	if len(pd.Errors) > 0 {
+		pd.Detail = MessageFor(CodeOf(pd.Errors[0]), DefaultLocale)
+		var pe ParseError
+		if errors.As(pd.Errors[0], &pe) && pe.Message != "" {
+			pd.Detail = pe.Message
+		}
+	}
+	return pd
+}
+
+// WriteProblem writes err to w as an application/problem+json response
+func WriteProblem(w http.ResponseWriter, err error) {
+	writeProblemDetails(w, NewProblemDetails(err))
+}
+
+func writeProblemDetails(w http.ResponseWriter, pd ProblemDetails) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(pd.Status)
+	_ = json.NewEncoder(w).Encode(pd)
+}
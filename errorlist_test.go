@@ -0,0 +1,63 @@
+package emailvalidator_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	emailvalidator "github.com/dcarbone/go-email-validator"
+)
+
+func TestErrorList_JSONRoundTrip(t *testing.T) {
+	_, err := emailvalidator.BuildResult("a b@exa_mple.com")
+
+	b, merr := json.Marshal(emailvalidator.ErrorListOf(err))
+	if merr != nil {
+		t.Fatalf("error marshalling: %v", merr)
+	}
+
+	var decoded emailvalidator.ErrorList
+	if uerr := json.Unmarshal(b, &decoded); uerr != nil {
+		t.Fatalf("error unmarshalling: %v", uerr)
+	}
+
+	if len(decoded) != 2 {
+		t.Fatalf("expected 2 errors, saw %d", len(decoded))
+	}
+	if !errors.Is(decoded, emailvalidator.ErrInvalidUnquotedSequence) {
+		t.Error("expected decoded list to contain ErrInvalidUnquotedSequence")
+	}
+	if !errors.Is(decoded, emailvalidator.ErrUnexpectedCharacter) {
+		t.Error("expected decoded list to contain ErrUnexpectedCharacter")
+	}
+	if decoded.Error() != err.Error() {
+		t.Errorf("expected message %q, saw %q", err.Error(), decoded.Error())
+	}
+	if perrs := emailvalidator.ErrorsOf(decoded.Err()); len(perrs) != 2 || perrs[1].Position != 7 {
+		t.Errorf("unexpected decoded diagnostics: %+v", perrs)
+	}
+}
+
+func TestWriteProblem(t *testing.T) {
+	_, err := emailvalidator.BuildResult("abc.example.com")
+
+	rec := httptest.NewRecorder()
+	emailvalidator.WriteProblem(rec, err)
+
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("expected status 422, saw %d", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/problem+json" {
+		t.Errorf("unexpected content type %q", ct)
+	}
+
+	var pd emailvalidator.ProblemDetails
+	if derr := json.NewDecoder(rec.Body).Decode(&pd); derr != nil {
+		t.Fatalf("error decoding problem: %v", derr)
+	}
+	if pd.Status != http.StatusUnprocessableEntity || pd.Detail != "The address is missing a domain." || len(pd.Errors) != 1 {
+		t.Errorf("unexpected problem: %+v", pd)
+	}
+}
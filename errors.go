@@ -0,0 +1,196 @@
+package emailvalidator
+
+import (
+	"errors"
+)
+
+// ErrorCode is a stable, machine-readable identifier for one of this package's sentinel errors.  Codes will never
+// be renamed or reused, so they are safe to persist and to switch on.
+type ErrorCode string
+
+const (
+	CodeEmptyInput                      ErrorCode = "empty_input"
+	CodeUnexpectedNonGraphicCharacter   ErrorCode = "unexpected_non_graphic_character"
+	CodePossibleHeaderInjection         ErrorCode = "possible_header_injection"
+	CodeBidiControlCharacter            ErrorCode = "bidi_control_character"
+	CodeInvisibleCharacter              ErrorCode = "invisible_character"
+	CodePercentDecoded                  ErrorCode = "percent_decoded"
+	CodeHTMLDecoded                     ErrorCode = "html_decoded"
+	CodeDisallowedLocalCharacter        ErrorCode = "disallowed_local_character"
+	CodeInvalidLiteralCharacter         ErrorCode = "invalid_literal_character"
+	CodeUnexpectedCharacter             ErrorCode = "unexpected_character"
+	CodeInvalidUnquotedSequence         ErrorCode = "invalid_unquoted_sequence"
+	CodeUnexpectedCharactersAfterDomain ErrorCode = "unexpected_characters_after_domain"
+	CodeZeroLengthLocalPart             ErrorCode = "zero_length_local_part"
+	CodeLocalPartTooLong                ErrorCode = "local_part_too_long"
+	CodeZeroLengthDomain                ErrorCode = "zero_length_domain"
+	CodeDomainTooLong                   ErrorCode = "domain_too_long"
+	CodeMailboxCharacterNotAllowed      ErrorCode = "mailbox_character_not_allowed"
+	CodeMailboxTooShort                 ErrorCode = "mailbox_too_short"
+	CodeMailboxTooLong                  ErrorCode = "mailbox_too_long"
+	CodeEmptySubAddress                 ErrorCode = "empty_sub_address"
+	CodeConsecutiveSeparators           ErrorCode = "consecutive_separators"
+	CodeInvalidAddressLiteral           ErrorCode = "invalid_address_literal"
+	CodeInvalidIPv6Literal              ErrorCode = "invalid_ipv6_literal"
+	CodeUnregisteredLiteralTag          ErrorCode = "unregistered_literal_tag"
+	CodeRoleAccount                     ErrorCode = "role_account"
+	CodeUppercaseLocal                  ErrorCode = "uppercase_local"
+	CodeEngineRejected                  ErrorCode = "engine_rejected"
+	CodeUnsafeAddress                   ErrorCode = "unsafe_address"
+	CodePolicyViolation                 ErrorCode = "policy_violation"
+	CodeCSVColumnMissing                ErrorCode = "csv_column_missing"
+	CodeRemoteValidator                 ErrorCode = "remote_validator"
+	CodeSinkDelivery                    ErrorCode = "sink_delivery"
+	CodeInvalidEnvelope                 ErrorCode = "invalid_envelope"
+	CodeLineTooLong                     ErrorCode = "line_too_long"
+
+	// CodeUnknown is returned by CodeOf for errors that do not originate from this package
+	CodeUnknown ErrorCode = "unknown"
+)
+
+type registryEntry struct {
+	code     ErrorCode
+	sentinel error
+}
+
+// registry maps each code to its sentinel.  Entries are ordered most specific first, as some sentinels wrap others.
+var registry = []registryEntry{
+	{CodeEmptyInput, ErrEmptyInput},
+	{CodeUnexpectedCharactersAfterDomain, ErrUnexpectedCharactersAfterDomain},
+	{CodeInvalidLiteralCharacter, ErrInvalidLiteralCharacter},
+	{CodeDisallowedLocalCharacter, ErrDisallowedLocalCharacter},
+	{CodeBidiControlCharacter, ErrBidiControlCharacter},
+	{CodeInvisibleCharacter, ErrInvisibleCharacter},
+	{CodePossibleHeaderInjection, ErrPossibleHeaderInjection},
+	{CodeUnexpectedNonGraphicCharacter, ErrUnexpectedNonGraphicCharacter},
+	{CodeUnexpectedCharacter, ErrUnexpectedCharacter},
+	{CodeInvalidUnquotedSequence, ErrInvalidUnquotedSequence},
+	{CodeZeroLengthLocalPart, ErrZeroLengthLocalPart},
+	{CodeLocalPartTooLong, ErrLocalPartTooLong},
+	{CodeZeroLengthDomain, ErrZeroLengthDomain},
+	{CodeDomainTooLong, ErrDomainTooLong},
+	{CodeMailboxCharacterNotAllowed, ErrMailboxCharacterNotAllowed},
+	{CodeMailboxTooShort, ErrMailboxTooShort},
+	{CodeMailboxTooLong, ErrMailboxTooLong},
+	{CodeEmptySubAddress, ErrEmptySubAddress},
+	{CodeConsecutiveSeparators, ErrConsecutiveSeparators},
+	{CodeInvalidIPv6Literal, ErrInvalidIPv6Literal},
+	{CodeInvalidAddressLiteral, ErrInvalidAddressLiteral},
+	{CodeUnregisteredLiteralTag, ErrUnregisteredLiteralTag},
+	{CodeRoleAccount, ErrRoleAccount},
+	{CodeUppercaseLocal, ErrUppercaseLocal},
+	{CodePercentDecoded, ErrPercentDecoded},
+	{CodeHTMLDecoded, ErrHTMLDecoded},
+	{CodeEngineRejected, ErrEngineRejected},
+	{CodeUnsafeAddress, ErrUnsafeAddress},
+	{CodePolicyViolation, ErrPolicyViolation},
+	{CodeCSVColumnMissing, ErrCSVColumnMissing},
+	{CodeRemoteValidator, ErrRemoteValidator},
+	{CodeSinkDelivery, ErrSinkDelivery},
+	{CodeInvalidEnvelope, ErrInvalidEnvelope},
+	{CodeLineTooLong, ErrLineTooLong},
+}
+
+// Codes returns every registered ErrorCode
+func Codes() []ErrorCode {
+	codes := make([]ErrorCode, len(registry))
+	for i, entry := range registry {
+		codes[i] = entry.code
+	}
+	return codes
+}
+
+// Sentinel returns the sentinel error identified by c, or nil if c is not registered
+func (c ErrorCode) Sentinel() error {
+	for _, entry := range registry {
+		if entry.code == c {
+			return entry.sentinel
+		}
+	}
+	return nil
+}
+
+// CodeOf returns the code of the most specific sentinel wrapped by err, or CodeUnknown
+func CodeOf(err error) ErrorCode {
+	for _, entry := range registry {
+		if errors.Is(err, entry.sentinel) {
+			return entry.code
+		}
+	}
+	return CodeUnknown
+}
+
+// Severity determines how a configurable check reports its findings
+type Severity string
+
+const (
+	// SeverityError causes a finding to fail validation.  The zero value of Severity is treated as SeverityError.
+	SeverityError Severity = "error"
+
+	// SeverityWarning records a finding in Result.Warnings without failing validation
+	SeverityWarning Severity = "warning"
+
+	// SeverityIgnore disables a check entirely
+	SeverityIgnore Severity = "ignore"
+)
+
+// Segment identifies the portion of an address a diagnostic pertains to
+type Segment string
+
+const (
+	SegmentLocal   Segment = "local"
+	SegmentComment Segment = "comment"
+	SegmentDomain  Segment = "domain"
+)
+
+// ParseError is a single typed diagnostic produced by BuildResult
+type ParseError struct {
+	// Code identifies the sentinel this error wraps
+	Code ErrorCode
+
+	// Position is the zero-indexed offset within the input the error was seen at, or -1 if the error pertains to a
+	// segment as a whole
+	Position int
+
+	// Char is the offending character, if any
+	Char string
+
+	// Segment is the portion of the address the error was seen in
+	Segment Segment
+
+	// Message is a human-readable description of the error in the configured locale, suitable for end users
+	Message string
+
+	// Err is the underlying error, which wraps the sentinel identified by Code
+	Err error
+}
+
+func (e ParseError) Error() string {
+	return e.Err.Error()
+}
+
+func (e ParseError) Unwrap() error {
+	return e.Err
+}
+
+func newParseError(err error, pos int, chr string, segment Segment) ParseError {
+	return ParseError{
+		Code:     CodeOf(err),
+		Position: pos,
+		Char:     chr,
+		Segment:  segment,
+		Err:      err,
+	}
+}
+
+// ErrorsOf returns every ParseError contained within err, which is typically the error returned by BuildResult
+func ErrorsOf(err error) []ParseError {
+	var out []ParseError
+	for _, e := range flattenErrors(err) {
+		var pe ParseError
+		if errors.As(e, &pe) {
+			out = append(out, pe)
+		}
+	}
+	return out
+}
@@ -0,0 +1,59 @@
+package emailvalidator_test
+
+import (
+	"errors"
+	"testing"
+
+	emailvalidator "github.com/dcarbone/go-email-validator"
+)
+
+func TestCodes(t *testing.T) {
+	seen := make(map[emailvalidator.ErrorCode]bool)
+	for _, code := range emailvalidator.Codes() {
+		if seen[code] {
+			t.Errorf("duplicate code %q", code)
+		}
+		seen[code] = true
+
+		sentinel := code.Sentinel()
+		if sentinel == nil {
+			t.Errorf("code %q has no sentinel", code)
+		} else if emailvalidator.CodeOf(sentinel) != code {
+			t.Errorf("expected CodeOf(%v) to be %q, saw %q", sentinel, code, emailvalidator.CodeOf(sentinel))
+		}
+	}
+}
+
+func TestErrorsOf(t *testing.T) {
+	res, err := emailvalidator.BuildResult("a b@exa_mple.com")
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if res.Err != err {
+		t.Error("expected Result.Err to be populated")
+	}
+	if _, ok := err.(interface{ Unwrap() []error }); !ok {
+		t.Error("expected joined error to implement Unwrap() []error")
+	}
+
+	perrs := emailvalidator.ErrorsOf(err)
+	if len(perrs) != 2 {
+		t.Fatalf("expected 2 errors, saw %v", perrs)
+	}
+
+	if pe := perrs[0]; pe.Code != emailvalidator.CodeInvalidUnquotedSequence ||
+		pe.Position != 1 ||
+		pe.Char != " " ||
+		pe.Segment != emailvalidator.SegmentLocal {
+		t.Errorf("unexpected first error: %+v", pe)
+	}
+	if pe := perrs[1]; pe.Code != emailvalidator.CodeUnexpectedCharacter ||
+		pe.Position != 7 ||
+		pe.Char != "_" ||
+		pe.Segment != emailvalidator.SegmentDomain {
+		t.Errorf("unexpected second error: %+v", pe)
+	}
+	if !errors.Is(perrs[1], emailvalidator.ErrUnexpectedCharacter) {
+		t.Error("expected ParseError to wrap its sentinel")
+	}
+}
@@ -0,0 +1,81 @@
+package emailvalidator
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// flagExplanations contains a short human-readable description of each Flag
+var flagExplanations = map[Flag]string{
+	FlagRole:           "role account",
+	FlagSubAddress:     "sub-addressed",
+	FlagQuoted:         "quoted local part",
+	FlagComment:        "contains a comment",
+	FlagAddressLiteral: "address literal domain",
+	FlagQuotedAt:       "quoted @ in local part",
+	FlagDisposable:     "disposable domain",
+	FlagFree:           "free mail provider",
+	FlagEmptyLocal:     "empty local part",
+	FlagSuppressed:     "on suppression list",
+	FlagReservedWord:   "reserved word in mailbox",
+	FlagPercentEncoded: "percent-encoded input",
+	FlagHTMLEncoded:    "html-encoded input",
+}
+
+// ExplainLines returns a concise human-readable reason for each error, warning, and flag within res, in that order.
+// Repeated reasons are only included once.
+func ExplainLines(res Result) []string {
+	var (
+		lines []string
+		seen  = make(map[string]bool)
+	)
+	add := func(prefix, reason string) {
+		reason = prefix + reason
+		if reason != prefix && !seen[reason] {
+			seen[reason] = true
+			lines = append(lines, reason)
+		}
+	}
+
+	for _, pe := range ErrorsOf(res.Err) {
+		add("", explainMessage(pe))
+	}
+	for _, pe := range res.Warnings {
+		add("warning: ", explainMessage(pe))
+	}
+	for _, fn := range flagNames {
+		if res.Flags.Has(fn.flag) {
+			add("", flagExplanations[fn.flag])
+		}
+	}
+
+	return lines
+}
+
+// Explain renders res as a single line suitable for showing to support agents, e.g.
+// "rejected: the address contains a character that is only allowed inside quotes; role account"
+func Explain(res Result) string {
+	verdict := "accepted"
+	if res.Err != nil {
+		verdict = "rejected"
+	}
+	lines := ExplainLines(res)
+	if len(lines) == 0 {
+		return verdict
+	}
+	return verdict + ": " + strings.Join(lines, "; ")
+}
+
+// explainMessage returns pe's message as a sentence fragment, falling back to the error text
+func explainMessage(pe ParseError) string {
+	msg := pe.Message
+	if msg == "" {
+		return pe.Error()
+	}
+	msg = strings.TrimSuffix(msg, ".")
+	if r, size := utf8.DecodeRuneInString(msg); unicode.IsUpper(r) {
+		msg = string(unicode.ToLower(r)) + msg[size:]
+	}
+	return msg
+}
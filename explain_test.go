@@ -0,0 +1,50 @@
+package emailvalidator_test
+
+import (
+	"testing"
+
+	emailvalidator "github.com/dcarbone/go-email-validator"
+)
+
+func TestExplain(t *testing.T) {
+	lists := emailvalidator.NewDomainLists()
+	lists.Set("disposable", []string{"mailinator.com"})
+
+	opts := []emailvalidator.OptFunc{
+		emailvalidator.WithDomainLists(lists),
+		emailvalidator.WithRoleAccountSeverity(emailvalidator.SeverityWarning, "sales"),
+	}
+
+	steps := []struct {
+		label string
+		input string
+		out   string
+	}{
+		{label: "ok", input: "jane@example.com", out: "accepted"},
+		{label: "flags", input: "jane@mailinator.com", out: "accepted: disposable domain"},
+		{
+			label: "warning",
+			input: "sales@example.com",
+			out:   "accepted: warning: the address belongs to a team or role rather than a person; role account",
+		},
+		{
+			label: "rejected",
+			input: "jane doe@mailinator.com",
+			out:   "rejected: the address contains a character that is only allowed inside quotes; disposable domain",
+		},
+		{
+			label: "repeated",
+			input: "jane  doe@example.com",
+			out:   "rejected: the address contains a character that is only allowed inside quotes",
+		},
+	}
+
+	for _, step := range steps {
+		t.Run(step.label, func(t *testing.T) {
+			res, _ := emailvalidator.BuildResult(step.input, opts...)
+			if out := emailvalidator.Explain(res); out != step.out {
+				t.Errorf("expected %q, saw %q", step.out, out)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package emailvalidator
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Flag is a single signal about an address that, while not making it invalid, may be relevant to risk decisions
+type Flag uint32
+
+const (
+	// FlagRole is set if the mailbox is one of ParseOptions.RoleAccounts
+	FlagRole Flag = 1 << iota
+
+	// FlagSubAddress is set if the local contains a sub-address, e.g. "user+tag"
+	FlagSubAddress
+
+	// FlagQuoted is set if the local contains a quoted section
+	FlagQuoted
+
+	// FlagComment is set if the address contains a comment
+	FlagComment
+
+	// FlagAddressLiteral is set if the domain is an address literal, e.g. "[127.0.0.1]"
+	FlagAddressLiteral
+
+	// FlagQuotedAt is set if the local contains a quoted "@"
+	FlagQuotedAt
+
+	// FlagDisposable is set if the domain is tagged "disposable" by ParseOptions.DomainLists
+	FlagDisposable
+
+	// FlagFree is set if the domain is tagged "free" by ParseOptions.DomainLists
+	FlagFree
+
+	// FlagEmptyLocal is set if the local is a quoted string with no content
+	FlagEmptyLocal
+
+	// FlagSuppressed is set if the address is likely within ParseOptions.SuppressionList
+	FlagSuppressed
+
+	// FlagReservedWord is set if the mailbox contains one of ParseOptions.ReservedWords
+	FlagReservedWord
+
+	// FlagPercentEncoded is set if the input contained percent-encoded characters
+	FlagPercentEncoded
+
+	// FlagHTMLEncoded is set if the input contained HTML character references
+	FlagHTMLEncoded
+)
+
+// flagNames contains the stable name of each Flag, in bit order.  Names will never be changed or reused.
+var flagNames = []struct {
+	flag Flag
+	name string
+}{
+	{FlagRole, "role"},
+	{FlagSubAddress, "sub_address"},
+	{FlagQuoted, "quoted"},
+	{FlagComment, "comment"},
+	{FlagAddressLiteral, "address_literal"},
+	{FlagQuotedAt, "quoted_at"},
+	{FlagDisposable, "disposable"},
+	{FlagFree, "free"},
+	{FlagEmptyLocal, "empty_local"},
+	{FlagSuppressed, "suppressed"},
+	{FlagReservedWord, "reserved_word"},
+	{FlagPercentEncoded, "percent_encoded"},
+	{FlagHTMLEncoded, "html_encoded"},
+}
+
+// Flags is a set of Flag values.  It serializes to JSON as an array of stable flag names.
+type Flags Flag
+
+// Has returns true if every bit of flag is set
+func (f Flags) Has(flag Flag) bool {
+	return Flag(f)&flag == flag
+}
+
+// Names returns the stable names of every set flag
+func (f Flags) Names() []string {
+	names := make([]string, 0)
+	for _, fn := range flagNames {
+		if f.Has(fn.flag) {
+			names = append(names, fn.name)
+		}
+	}
+	return names
+}
+
+func (f Flags) String() string {
+	return strings.Join(f.Names(), ",")
+}
+
+func (f Flags) MarshalJSON() ([]byte, error) {
+	return json.Marshal(f.Names())
+}
+
+func (f *Flags) UnmarshalJSON(b []byte) error {
+	var names []string
+	if err := json.Unmarshal(b, &names); err != nil {
+		return err
+	}
+	*f = 0
+next:
+	for _, name := range names {
+		for _, fn := range flagNames {
+			if fn.name == name {
+				*f |= Flags(fn.flag)
+				continue next
+			}
+		}
+		return fmt.Errorf("unknown flag %q", name)
+	}
+	return nil
+}
+
+// flagsOf computes the Flags of a fully parsed res
+func flagsOf(res *Result) Flags {
+	var f Flag
+	if res.RoleAccount {
+		f |= FlagRole
+	}
+	if res.SubAddress != "" {
+		f |= FlagSubAddress
+	}
+	if res.Quoted {
+		f |= FlagQuoted
+	}
+	if res.Comment != "" || res.TrailingComment != "" {
+		f |= FlagComment
+	}
+	if res.LiteralDomain {
+		f |= FlagAddressLiteral
+	}
+	if res.LocalContainsAt {
+		f |= FlagQuotedAt
+	}
+	if res.EmptyLocal {
+		f |= FlagEmptyLocal
+	}
+	if res.Suppressed {
+		f |= FlagSuppressed
+	}
+	if res.ReservedWord != "" {
+		f |= FlagReservedWord
+	}
+	if res.PercentEncoded {
+		f |= FlagPercentEncoded
+	}
+	if res.HTMLEncoded {
+		f |= FlagHTMLEncoded
+	}
+	for _, tag := range res.DomainTags {
+		switch tag {
+		case "disposable":
+			f |= FlagDisposable
+		case "free":
+			f |= FlagFree
+		}
+	}
+	return Flags(f)
+}
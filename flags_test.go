@@ -0,0 +1,70 @@
+package emailvalidator_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	emailvalidator "github.com/dcarbone/go-email-validator"
+)
+
+func TestResult_Flags(t *testing.T) {
+	lists := emailvalidator.NewDomainLists()
+	lists.Set("free", []string{"gmail.com"})
+
+	opts := []emailvalidator.OptFunc{
+		emailvalidator.WithDomainLists(lists),
+		emailvalidator.WithRoleAccountSeverity(emailvalidator.SeverityWarning, "sales"),
+		func(opt *emailvalidator.ParseOptions) { opt.SubAddressSeparators = "+" },
+	}
+
+	steps := []struct {
+		input string
+		flags string
+	}{
+		{"user@example.com", ""},
+		{"sales+leads@gmail.com", "role,sub_address,free"},
+		{`"a@b"@example.com (work)`, "quoted,comment,quoted_at"},
+		{"user@[127.0.0.1]", "address_literal"},
+	}
+	for _, step := range steps {
+		res, err := emailvalidator.BuildResult(step.input, opts...)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", step.input, err)
+		}
+		if res.Flags.String() != step.flags {
+			t.Errorf("%s: expected flags %q, saw %q", step.input, step.flags, res.Flags)
+		}
+	}
+}
+
+func TestFlags_JSON(t *testing.T) {
+	res, _ := emailvalidator.BuildResult(`"a@b"@example.com`)
+	if !res.Flags.Has(emailvalidator.FlagQuoted) || !res.Flags.Has(emailvalidator.FlagQuotedAt) {
+		t.Fatalf("expected quoted flags, saw %q", res.Flags)
+	}
+
+	b, err := json.Marshal(res.Flags)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(b) != `["quoted","quoted_at"]` {
+		t.Errorf("unexpected json: %s", b)
+	}
+
+	var decoded emailvalidator.Flags
+	if err = json.Unmarshal(b, &decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if decoded != res.Flags {
+		t.Errorf("expected %q, saw %q", res.Flags, decoded)
+	}
+
+	if err = json.Unmarshal([]byte(`["bogus"]`), &decoded); err == nil {
+		t.Error("expected error for unknown flag")
+	}
+
+	var none emailvalidator.Flags
+	if b, _ = json.Marshal(none); string(b) != "[]" {
+		t.Errorf("expected empty array, saw %s", b)
+	}
+}
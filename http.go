@@ -0,0 +1,60 @@
+package emailvalidator
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// ValidateResponse is the body written by Handler for each validated address
+type ValidateResponse struct {
+	Result  Result    `json:"result"`
+	Verdict Verdict   `json:"verdict"`
+	Errors  ErrorList `json:"errors,omitempty"`
+}
+
+// Handler validates the address provided in the "email" query or form parameter, responding with a JSON-encoded
+// ValidateResponse, or a Report if the "format" parameter is "report".  Invalid addresses are not an HTTP error; the
+// status code is only non-200 when the request itself is malformed.
+type Handler struct {
+	validator EmailValidator
+}
+
+func NewHandler(validator EmailValidator) *Handler {
+	return &Handler{validator: validator}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	email := r.FormValue("email")
+	if email == "" {
+		http.Error(w, `missing "email" parameter`, http.StatusBadRequest)
+		return
+	}
+
+	start := time.Now()
+	res, err := h.validator.Validate(r.Context(), email)
+	if err != nil && r.Context().Err() != nil {
+		// client went away, nothing useful to write.
+		return
+	}
+
+	var resp any
+	if r.FormValue("format") == "report" {
+		resp = NewReport(res, err, time.Since(start))
+	} else {
+		resp = ValidateResponse{
+			Result:  res,
+			Verdict: VerdictOf(err),
+			Errors:  ErrorListOf(err),
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,55 @@
+package emailvalidator_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	emailvalidator "github.com/dcarbone/go-email-validator"
+)
+
+func TestClient_Handler(t *testing.T) {
+	srv := httptest.NewServer(emailvalidator.NewHandler(emailvalidator.NewValidator()))
+	defer srv.Close()
+
+	var client emailvalidator.EmailValidator = emailvalidator.NewClient(srv.URL, srv.Client())
+
+	res, err := client.Validate(context.Background(), "simple@example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Local != "simple" || res.Domain != "example.com" {
+		t.Errorf("unexpected result: %+v", res)
+	}
+
+	_, err = client.Validate(context.Background(), "a@b@c@example.com")
+	if !errors.Is(err, emailvalidator.ErrUnexpectedCharacter) {
+		t.Errorf("expected ErrUnexpectedCharacter, saw %v", err)
+	}
+
+	_, err = client.Validate(context.Background(), "abc.example.com")
+	if !errors.Is(err, emailvalidator.ErrZeroLengthDomain) {
+		t.Errorf("expected ErrZeroLengthDomain, saw %v", err)
+	}
+}
+
+func TestHandler_MissingEmail(t *testing.T) {
+	rec := httptest.NewRecorder()
+	emailvalidator.NewHandler(emailvalidator.NewValidator()).
+		ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, saw %d", rec.Code)
+	}
+}
+
+func TestClient_BadStatus(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	_, err := emailvalidator.NewClient(srv.URL, nil).Validate(context.Background(), "simple@example.com")
+	if !errors.Is(err, emailvalidator.ErrRemoteValidator) {
+		t.Errorf("expected ErrRemoteValidator, saw %v", err)
+	}
+}
@@ -0,0 +1,130 @@
+package emailvalidator
+
+import (
+	"crypto/subtle"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// APIKeyHeader is the request header RequireAPIKeys reads keys from.  Keys may alternatively be provided as a bearer
+// token in the Authorization header.
+const APIKeyHeader = "X-API-Key"
+
+// APIKey is a single credential accepted by RequireAPIKeys
+type APIKey struct {
+	// Key is the secret value clients must provide
+	Key string
+
+	// RequestsPerSecond, if greater than zero, limits the sustained rate of requests made with this key
+	RequestsPerSecond float64
+
+	// Burst is the number of requests that may be made at once before RequestsPerSecond applies.  Defaults to 1.
+	Burst int
+}
+
+// bucket is a token bucket limiting the requests made with a single key
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// keyAuth is the http.Handler returned by RequireAPIKeys
+type keyAuth struct {
+	next http.Handler
+	keys []APIKey
+
+	mu      sync.Mutex
+	buckets []bucket
+}
+
+// RequireAPIKeys wraps next, typically a Handler, so that only requests bearing one of keys are served.  Requests
+// with a missing or unknown key receive a 401, and requests exceeding their key's rate limit receive a 429 with a
+// Retry-After header.
+func RequireAPIKeys(next http.Handler, keys ...APIKey) http.Handler {
+	ka := &keyAuth{
+		next:    next,
+		keys:    keys,
+		buckets: make([]bucket, len(keys)),
+	}
+	for i, key := range keys {
+		ka.buckets[i].tokens = float64(burstOf(key))
+	}
+	return ka
+}
+
+func (ka *keyAuth) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	idx := ka.lookup(requestAPIKey(r))
+	if idx == -1 {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="email-validator"`)
+		http.Error(w, "missing or invalid api key", http.StatusUnauthorized)
+		return
+	}
+
+	if wait := ka.take(idx, time.Now()); wait > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+		http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+		return
+	}
+
+	ka.next.ServeHTTP(w, r)
+}
+
+// lookup returns the index of key within ka.keys, or -1.  Every key is compared, in constant time, so as to not leak
+// which keys exist.
+func (ka *keyAuth) lookup(key string) int {
+	idx := -1
+	if key == "" {
+		return idx
+	}
+	for i, candidate := range ka.keys {
+		if subtle.ConstantTimeCompare([]byte(key), []byte(candidate.Key)) == 1 {
+			idx = i
+		}
+	}
+	return idx
+}
+
+// take consumes a token from the bucket of the key at idx, returning how long the caller must wait if none remain
+func (ka *keyAuth) take(idx int, now time.Time) time.Duration {
+	rate := ka.keys[idx].RequestsPerSecond
+	if rate <= 0 {
+		return 0
+	}
+
+	ka.mu.Lock()
+	defer ka.mu.Unlock()
+
+	b := &ka.buckets[idx]
+	if !b.last.IsZero() {
+		b.tokens = min(float64(burstOf(ka.keys[idx])), b.tokens+now.Sub(b.last).Seconds()*rate)
+	}
+	b.last = now
+
+	if b.tokens < 1 {
+		return time.Duration((1 - b.tokens) / rate * float64(time.Second))
+	}
+	b.tokens--
+	return 0
+}
+
+func burstOf(key APIKey) int {
+	if key.Burst > 0 {
+		return key.Burst
+	}
+	return 1
+}
+
+// requestAPIKey returns the key provided with r, if any
+func requestAPIKey(r *http.Request) string {
+	if key := r.Header.Get(APIKeyHeader); key != "" {
+		return key
+	}
+	if auth := r.Header.Get("Authorization"); len(auth) > 7 && strings.EqualFold(auth[:7], "bearer ") {
+		return strings.TrimSpace(auth[7:])
+	}
+	return ""
+}
@@ -0,0 +1,50 @@
+package emailvalidator_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	emailvalidator "github.com/dcarbone/go-email-validator"
+)
+
+func TestRequireAPIKeys(t *testing.T) {
+	handler := emailvalidator.RequireAPIKeys(
+		emailvalidator.NewHandler(emailvalidator.NewValidator()),
+		emailvalidator.APIKey{Key: "unlimited"},
+		emailvalidator.APIKey{Key: "limited", RequestsPerSecond: 0.001, Burst: 2},
+	)
+
+	steps := []struct {
+		label  string
+		header string
+		value  string
+		code   int
+	}{
+		{label: "missing", code: http.StatusUnauthorized},
+		{label: "unknown", header: emailvalidator.APIKeyHeader, value: "nope", code: http.StatusUnauthorized},
+		{label: "header", header: emailvalidator.APIKeyHeader, value: "unlimited", code: http.StatusOK},
+		{label: "bearer", header: "Authorization", value: "Bearer unlimited", code: http.StatusOK},
+		{label: "limited-1", header: emailvalidator.APIKeyHeader, value: "limited", code: http.StatusOK},
+		{label: "limited-2", header: emailvalidator.APIKeyHeader, value: "limited", code: http.StatusOK},
+		{label: "limited-3", header: emailvalidator.APIKeyHeader, value: "limited", code: http.StatusTooManyRequests},
+		{label: "unlimited", header: emailvalidator.APIKeyHeader, value: "unlimited", code: http.StatusOK},
+	}
+
+	for _, step := range steps {
+		t.Run(step.label, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/?email=simple@example.com", nil)
+			if step.header != "" {
+				req.Header.Set(step.header, step.value)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != step.code {
+				t.Errorf("expected status %d, saw %d", step.code, rec.Code)
+			}
+			if step.code == http.StatusTooManyRequests && rec.Header().Get("Retry-After") == "" {
+				t.Error("expected Retry-After header")
+			}
+		})
+	}
+}
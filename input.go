@@ -0,0 +1,254 @@
+package emailvalidator
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var (
+	ErrLineTooLong = errors.New("line exceeds maximum length")
+)
+
+// InputFormat identifies the layout of address lists read by WriteReports
+type InputFormat string
+
+const (
+	// FormatAuto detects the format from the first line of input.  It is the zero value of InputFormat.
+	FormatAuto InputFormat = ""
+
+	// FormatLines is one address per line
+	FormatLines InputFormat = "lines"
+
+	// FormatCSV is comma-separated records, optionally with a header row
+	FormatCSV InputFormat = "csv"
+
+	// FormatTSV is tab-separated records, optionally with a header row
+	FormatTSV InputFormat = "tsv"
+
+	// FormatJSONL is one JSON object per line, the address being a string member
+	FormatJSONL InputFormat = "jsonl"
+)
+
+// DefaultInputField is the header column or JSON member holding addresses when BulkOptions.InputField is empty
+const DefaultInputField = "email"
+
+// MaxLineLength is the length in bytes, excluding its terminator, of the longest line read from plain line or JSONL
+// input.  Longer lines are skipped and reported as a single record failing with ErrLineTooLong.
+const MaxLineLength = 1 << 20
+
+// DetectFormat guesses the InputFormat of a list from its first line.  Lines beginning with "{" are FormatJSONL,
+// lines containing a tab are FormatTSV, and lines containing a comma are FormatCSV.  Anything else is FormatLines.
+func DetectFormat(firstLine string) InputFormat {
+	line := strings.TrimSpace(strings.TrimPrefix(firstLine, "\ufeff"))
+	switch {
+	case strings.HasPrefix(line, "{"):
+		return FormatJSONL
+	case strings.ContainsRune(line, '\t'):
+		return FormatTSV
+	case strings.ContainsRune(line, ','):
+		return FormatCSV
+	default:
+		return FormatLines
+	}
+}
+
+// WithInputFormat sets the format of input read by WriteReports, and the header column or JSON member containing
+// addresses.  An empty field uses DefaultInputField.
+func WithInputFormat(format InputFormat, field string) BulkOptFunc {
+	return func(opt *BulkOptions) {
+		opt.InputFormat = format
+		opt.InputField = field
+	}
+}
+
+// addressReader yields the address within each record of a list
+type addressReader interface {
+	// next returns the fields identifying the record, for use as a Journal key, and the address within it.  A
+	// missing address is reported via ErrCSVColumnMissing, and an overlong line via ErrLineTooLong, and reading may
+	// continue after either.  io.EOF is returned once input is exhausted.
+	next() (fields []string, address string, err error)
+}
+
+// newAddressReader returns an addressReader for r, detecting its format if bulkOpts.InputFormat is FormatAuto
+func newAddressReader(r io.Reader, bulkOpts BulkOptions) (addressReader, error) {
+	br := bufio.NewReader(r)
+	if bom, _ := br.Peek(3); string(bom) == "\ufeff" {
+		_, _ = br.Discard(3)
+	}
+
+	format := bulkOpts.InputFormat
+	if format == FormatAuto {
+		// a failed peek returns whatever is available, which is all detection needs
+		peek, _ := br.Peek(4096)
+		if i := bytes.IndexByte(peek, '\n'); i >= 0 {
+			peek = peek[:i]
+		}
+		format = DetectFormat(string(peek))
+	}
+
+	field := bulkOpts.InputField
+	if field == "" {
+		field = DefaultInputField
+	}
+
+	switch format {
+	case FormatLines:
+		return &lineReader{br: br}, nil
+	case FormatJSONL:
+		return &jsonlReader{br: br, field: field}, nil
+	case FormatCSV, FormatTSV:
+		cr := csv.NewReader(br)
+		cr.FieldsPerRecord = -1
+		if format == FormatTSV {
+			cr.Comma = '\t'
+			cr.LazyQuotes = true
+		}
+		return newTabularReader(cr, field, bulkOpts.HasHeader)
+	default:
+		return nil, fmt.Errorf("unknown input format %q", format)
+	}
+}
+
+// readLine returns the next line from br without its terminator, as bufio.ScanLines would.  A line longer than
+// MaxLineLength is discarded through to its newline, and ErrLineTooLong returned in its place.
+func readLine(br *bufio.Reader) (string, error) {
+	var buf []byte
+	for {
+		chunk, err := br.ReadSlice('\n')
+		// stop accumulating once the line is known to be too long, but keep reading to find its end
+		if len(buf) <= MaxLineLength+len("\r\n") {
+			buf = append(buf, chunk...)
+		}
+		if errors.Is(err, bufio.ErrBufferFull) {
+			continue
+		} else if errors.Is(err, io.EOF) && len(buf) == 0 {
+			return "", io.EOF
+		} else if err != nil && !errors.Is(err, io.EOF) {
+			return "", err
+		}
+
+		line := bytes.TrimSuffix(bytes.TrimSuffix(buf, []byte("\n")), []byte("\r"))
+		if len(line) > MaxLineLength {
+			return "", fmt.Errorf("%w: %d bytes", ErrLineTooLong, MaxLineLength)
+		}
+		return string(line), nil
+	}
+}
+
+type lineReader struct {
+	br   *bufio.Reader
+	line int
+}
+
+func (lr *lineReader) next() ([]string, string, error) {
+	text, err := readLine(lr.br)
+	if errors.Is(err, io.EOF) {
+		return nil, "", io.EOF
+	}
+	lr.line++
+	if errors.Is(err, ErrLineTooLong) {
+		return nil, "", fmt.Errorf("line %d: %w", lr.line, err)
+	} else if err != nil {
+		return nil, "", fmt.Errorf("error reading addresses: %w", err)
+	}
+	return []string{text}, text, nil
+}
+
+type jsonlReader struct {
+	br    *bufio.Reader
+	field string
+	line  int
+}
+
+func (jr *jsonlReader) next() ([]string, string, error) {
+	for {
+		text, err := readLine(jr.br)
+		if errors.Is(err, io.EOF) {
+			return nil, "", io.EOF
+		}
+		jr.line++
+		if errors.Is(err, ErrLineTooLong) {
+			return nil, "", fmt.Errorf("line %d: %w", jr.line, err)
+		} else if err != nil {
+			return nil, "", fmt.Errorf("error reading addresses: %w", err)
+		}
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+
+		var record map[string]json.RawMessage
+		if err := json.Unmarshal([]byte(text), &record); err != nil {
+			return nil, "", fmt.Errorf("error reading addresses: line %d: %w", jr.line, err)
+		}
+		var address string
+		if raw, ok := record[jr.field]; !ok || json.Unmarshal(raw, &address) != nil {
+			return []string{text}, "", fmt.Errorf("%w: line %d has no string %q member", ErrCSVColumnMissing, jr.line, jr.field)
+		}
+		return []string{text}, address, nil
+	}
+}
+
+type tabularReader struct {
+	cr      *csv.Reader
+	column  int
+	pending []string
+	row     int
+}
+
+// newTabularReader determines which column of cr holds addresses.  The first record is treated as a header if
+// hasHeader is true or none of its values contain "@", in which case the column named field is used.  Otherwise, the
+// first column of the first record containing "@" is used.
+func newTabularReader(cr *csv.Reader, field string, hasHeader bool) (*tabularReader, error) {
+	tr := &tabularReader{cr: cr, column: -1}
+
+	first, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return tr, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error reading addresses: %w", err)
+	}
+
+	tr.row++
+	if !hasHeader {
+		for i, value := range first {
+			if strings.ContainsRune(value, '@') {
+				tr.column, tr.pending = i, first
+				return tr, nil
+			}
+		}
+	}
+	for i, name := range first {
+		if strings.EqualFold(strings.TrimSpace(name), field) {
+			tr.column = i
+			break
+		}
+	}
+	if tr.column < 0 {
+		return nil, fmt.Errorf("%w: header has no %q column", ErrCSVColumnMissing, field)
+	}
+	return tr, nil
+}
+
+func (tr *tabularReader) next() ([]string, string, error) {
+	record := tr.pending
+	tr.pending = nil
+	if record == nil {
+		var err error
+		if record, err = tr.cr.Read(); errors.Is(err, io.EOF) {
+			return nil, "", io.EOF
+		} else if err != nil {
+			return nil, "", fmt.Errorf("error reading addresses: %w", err)
+		}
+		tr.row++
+	}
+	if tr.column < 0 || tr.column >= len(record) {
+		return record, "", fmt.Errorf("%w: row %d has %d columns", ErrCSVColumnMissing, tr.row, len(record))
+	}
+	return record, record[tr.column], nil
+}
@@ -0,0 +1,157 @@
+package emailvalidator_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	emailvalidator "github.com/dcarbone/go-email-validator"
+)
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		line string
+		want emailvalidator.InputFormat
+	}{
+		{"user@example.com", emailvalidator.FormatLines},
+		{"name,email", emailvalidator.FormatCSV},
+		{"name\temail", emailvalidator.FormatTSV},
+		{"{\"email\": \"user@example.com\"}", emailvalidator.FormatJSONL},
+		{"\ufeffname,email", emailvalidator.FormatCSV},
+		{"", emailvalidator.FormatLines},
+	}
+	for _, tt := range tests {
+		if got := emailvalidator.DetectFormat(tt.line); got != tt.want {
+			t.Errorf("DetectFormat(%q): expected %q, saw %q", tt.line, tt.want, got)
+		}
+	}
+}
+
+func TestWriteReports_InputFormats(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		opts  []emailvalidator.BulkOptFunc
+		want  []string
+	}{
+		{
+			name:  "lines",
+			input: "a@example.com\nb@example.com\n",
+			want:  []string{"a@example.com", "b@example.com"},
+		},
+		{
+			name:  "csv header",
+			input: "\ufeffName,Email\nJane,jane@example.com\n\"Doe, John\",john@example.com\n",
+			want:  []string{"jane@example.com", "john@example.com"},
+		},
+		{
+			name:  "csv no header",
+			input: "Jane,jane@example.com\nJohn,john@example.com\n",
+			want:  []string{"jane@example.com", "john@example.com"},
+		},
+		{
+			name:  "tsv header",
+			input: "id\tcontact\n1\tjane@example.com\n",
+			opts:  []emailvalidator.BulkOptFunc{emailvalidator.WithInputFormat(emailvalidator.FormatAuto, "contact")},
+			want:  []string{"jane@example.com"},
+		},
+		{
+			name:  "jsonl",
+			input: "{\"id\":1,\"email\":\"jane@example.com\"}\n\n{\"id\":2,\"email\":\"john@example.com\"}\n",
+			want:  []string{"jane@example.com", "john@example.com"},
+		},
+		{
+			name:  "explicit lines",
+			input: "\"a,b\"@example.com\n",
+			opts:  []emailvalidator.BulkOptFunc{emailvalidator.WithInputFormat(emailvalidator.FormatLines, "")},
+			want:  []string{"\"a,b\"@example.com"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			summary, err := emailvalidator.WriteReports(context.Background(), strings.NewReader(tt.input), &buf, tt.opts...)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if summary.Verdicts[emailvalidator.VerdictValid] != len(tt.want) {
+				t.Errorf("expected %d valid addresses, saw %+v", len(tt.want), summary)
+			}
+
+			var got []string
+			dec := json.NewDecoder(&buf)
+			for dec.More() {
+				var rep emailvalidator.Report
+				if err = dec.Decode(&rep); err != nil {
+					t.Fatalf("error decoding report: %v", err)
+				}
+				got = append(got, rep.Input)
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("expected inputs %q, saw %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestWriteReports_InputFieldMissing(t *testing.T) {
+	var buf bytes.Buffer
+	summary, err := emailvalidator.WriteReports(
+		context.Background(),
+		strings.NewReader("{\"email\":\"jane@example.com\"}\n{\"mail\":\"john@example.com\"}\n"),
+		&buf,
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if summary.Total != 2 || summary.Errors[emailvalidator.CodeCSVColumnMissing] != 1 {
+		t.Errorf("unexpected summary: %+v", summary)
+	}
+
+	_, err = emailvalidator.WriteReports(context.Background(), strings.NewReader("name,phone\nJane,555\n"), &buf)
+	if !errors.Is(err, emailvalidator.ErrCSVColumnMissing) {
+		t.Errorf("expected ErrCSVColumnMissing, saw %v", err)
+	}
+
+	_, err = emailvalidator.WriteReports(context.Background(), strings.NewReader("{not json}\n"), &buf)
+	if err == nil {
+		t.Error("expected error reading malformed jsonl")
+	}
+}
+
+func TestWriteReports_LineTooLong(t *testing.T) {
+	steps := []struct {
+		format emailvalidator.InputFormat
+		record func(string) string
+	}{
+		{format: emailvalidator.FormatLines, record: func(address string) string { return address }},
+		{format: emailvalidator.FormatJSONL, record: func(address string) string { return `{"email":"` + address + `"}` }},
+	}
+
+	for _, step := range steps {
+		t.Run(string(step.format), func(t *testing.T) {
+			long := strings.Repeat("x", emailvalidator.MaxLineLength+1)
+			input := step.record("jane@example.com") + "\n" +
+				long + "\r\n" +
+				step.record(strings.Repeat("y", emailvalidator.MaxLineLength-len(step.record("")))) + "\n" +
+				step.record("john@example.com")
+
+			var buf bytes.Buffer
+			summary, err := emailvalidator.WriteReports(
+				context.Background(),
+				strings.NewReader(input),
+				&buf,
+				emailvalidator.WithInputFormat(step.format, ""),
+			)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if summary.Total != 4 || summary.Verdicts[emailvalidator.VerdictValid] != 2 || summary.Errors[emailvalidator.CodeLineTooLong] != 1 {
+				t.Errorf("unexpected summary: %+v", summary)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package emailvalidator
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	ErrInvisibleCharacter = fmt.Errorf("%w: invisible character", ErrUnexpectedCharacter)
+)
+
+// isInvisible returns true if r renders with no width, as is the case with the zero-width characters frequently
+// carried along with addresses copied from web pages, chat clients, and word processors
+func isInvisible(r rune) bool {
+	switch r {
+	case '\u00ad', // soft hyphen
+		'\u034f', // combining grapheme joiner
+		'\u115f', // hangul choseong filler
+		'\u1160', // hangul jungseong filler
+		'\u180e', // mongolian vowel separator
+		'\u200b', // zero width space
+		'\u200c', // zero width non-joiner
+		'\u200d', // zero width joiner
+		'\u2060', // word joiner
+		'\u2061', // function application
+		'\u2062', // invisible times
+		'\u2063', // invisible separator
+		'\u2064', // invisible plus
+		'\u3164', // hangul filler
+		'\ufeff', // zero width no-break space
+		'\uffa0': // halfwidth hangul filler
+		return true
+	}
+	return false
+}
+
+// checkInvisible returns an error describing the first invisible character within email, or nil if there are none
+func checkInvisible(email string) *ParseError {
+	inDomain := false
+	for i, r := range email {
+		if r == '@' {
+			inDomain = true
+		}
+		if r < utf8.RuneSelf || !isInvisible(r) {
+			continue
+		}
+		pe := newParseError(
+			fmt.Errorf("%w: %U at position %d", ErrInvisibleCharacter, r, i),
+			i,
+			string(r),
+			currentSegment(false, inDomain),
+		)
+		return &pe
+	}
+	return nil
+}
+
+// stripInvisible returns email with all invisible characters removed
+func stripInvisible(email string) string {
+	return strings.Map(func(r rune) rune {
+		if isInvisible(r) {
+			return -1
+		}
+		return r
+	}, email)
+}
@@ -3,6 +3,8 @@ package emailvalidator
 import (
 	"errors"
 	"fmt"
+	"net/netip"
+	"strings"
 )
 
 const (
@@ -19,6 +21,20 @@ var (
 	ErrLocalPartTooLong                = errors.New("local part length exceeds 64 characters")
 	ErrZeroLengthDomain                = errors.New("zero-length domain")
 	ErrDomainTooLong                   = errors.New("domain length exceeds 64 characters")
+	ErrMailboxCharacterNotAllowed      = errors.New("character not allowed in mailbox")
+	ErrMailboxTooShort                 = errors.New("mailbox length below configured minimum")
+	ErrMailboxTooLong                  = errors.New("mailbox length exceeds configured maximum")
+	ErrEmptySubAddress                 = errors.New("empty sub-address")
+	ErrConsecutiveSeparators           = errors.New("consecutive sub-address separators exceed configured maximum")
+	ErrInvalidAddressLiteral           = errors.New("invalid address literal")
+	ErrUnregisteredLiteralTag          = errors.New("unregistered address literal tag")
+	ErrInvalidIPv6Literal              = fmt.Errorf("%w: ipv6", ErrInvalidAddressLiteral)
+	ErrInvalidLiteralCharacter         = fmt.Errorf("%w: in address literal", ErrUnexpectedCharacter)
+	ErrRoleAccount                     = errors.New("role account")
+	ErrPossibleHeaderInjection         = fmt.Errorf("%w: possible header injection", ErrUnexpectedNonGraphicCharacter)
+	ErrDisallowedLocalCharacter        = fmt.Errorf("%w: disallowed in local", ErrUnexpectedCharacter)
+	ErrUppercaseLocal                  = errors.New("local part contains uppercase letters")
+	ErrEmptyInput                      = errors.New("empty input")
 )
 
 type ParseOptions struct {
@@ -26,51 +42,254 @@ type ParseOptions struct {
 	//AllowSmtpUtf8 bool
 
 	// TrackCharacterPositions, if true, will cause the CharacterPositions map to be defined in the result
-	TrackCharacterPositions bool
+	TrackCharacterPositions bool `json:"track_character_positions"`
+
+	// MaxPositionRuns caps the number of PositionRuns recorded when tracking character positions.  Defaults to
+	// DefaultMaxPositionRuns.
+	MaxPositionRuns int `json:"max_position_runs"`
+
+	// SubAddressSeparators, if non-empty, lists the characters that mark the beginning of a sub-address within an
+	// unquoted local part, e.g. "+".
+	SubAddressSeparators string `json:"sub_address_separators"`
+
+	// MaxConsecutiveSeparators, if greater than zero, caps the number of consecutive sub-address separators allowed
+	// in the local, e.g. a value of 1 rejects "user++tag".
+	MaxConsecutiveSeparators int `json:"max_consecutive_separators"`
+
+	// SubAddressSeverity determines how degenerate sub-addresses, i.e. empty tags and excess consecutive separators,
+	// are reported.  SeverityError, the default, fails validation.  SeverityWarning adds to Result.Warnings.
+	// SeverityIgnore disables these checks.
+	SubAddressSeverity Severity `json:"sub_address_severity"`
+
+	// MailboxAllowedCharacters, if non-empty, restricts the characters that may appear in the mailbox, i.e. the local
+	// part minus any sub-address.
+	MailboxAllowedCharacters string `json:"mailbox_allowed_characters"`
+
+	// MailboxMinLength, if greater than zero, is the minimum allowed length of the mailbox
+	MailboxMinLength int `json:"mailbox_min_length"`
+
+	// MailboxMaxLength, if greater than zero, is the maximum allowed length of the mailbox
+	MailboxMaxLength int `json:"mailbox_max_length"`
+
+	// Locale selects the message catalog used to populate ParseError.Message.  Defaults to English.
+	Locale string `json:"locale"`
+
+	// Messages, if defined, overrides the catalog message for the codes it contains
+	Messages Messages `json:"messages,omitempty"`
+
+	// MessageFormatter, if defined, takes precedence over Locale and Messages when populating ParseError.Message
+	MessageFormatter MessageFormatter `json:"-"`
+
+	// StrictLiteralTags, if true, will cause general address literals to be rejected unless their tag is registered
+	// with IANA's "Address Literal Tags" registry.
+	StrictLiteralTags bool `json:"strict_literal_tags"`
+
+	// BehaviorVersion selects the parsing behavior to use, allowing fixes that change which addresses are accepted to
+	// be adopted deliberately.  Defaults to BehaviorVersion1.
+	BehaviorVersion int `json:"behavior_version"`
+
+	// DomainLists, if defined, is used to populate Result.DomainTags
+	DomainLists *DomainLists `json:"-"`
+
+	// RoleAccounts maps lower-cased role mailbox names, e.g. "postmaster", to the Severity with which they are
+	// reported.  SeverityIgnore flags the role in Result.RoleAccount without any diagnostic.
+	RoleAccounts map[string]Severity `json:"role_accounts,omitempty"`
+
+	// StrictDotAtom, if true, requires the local to be an RFC 5321 Dot-string or Quoted-string: comments within or
+	// around the local are rejected, atoms may not be empty, and quoted sections may not be mixed with unquoted atoms.
+	StrictDotAtom bool `json:"strict_dot_atom"`
+
+	// RejectEmptyLocal, if true, rejects addresses whose local is a zero-length quoted string, e.g. `""@example.com`
+	RejectEmptyLocal bool `json:"reject_empty_local"`
+
+	// MaxCommentDepth, if greater than zero, is the maximum allowed nesting depth of comments, e.g. 2 allows "((a)b)".
+	// Defaults to 1, i.e. no nesting, or DefaultMaxCommentDepth as of BehaviorVersion3.
+	MaxCommentDepth int `json:"max_comment_depth"`
+
+	// ReservedWords contains words to screen mailboxes for, compared case-insensitively and ignoring ".", "-", and "_".
+	// Matches are reported via Result.ReservedWord and FlagReservedWord, and never fail validation.  Matching is by
+	// substring, so short words may match innocuous mailboxes.  See WithReservedWords.
+	ReservedWords []string `json:"reserved_words,omitempty"`
+
+	// WarnUppercaseLocal, if true, adds an ErrUppercaseLocal warning to results whose local contains uppercase letters.
+	// Locals are case-sensitive per RFC 5321, and while most receivers ignore case, some do not.
+	WarnUppercaseLocal bool `json:"warn_uppercase_local"`
+
+	// LowercaseLocal, if true, lowercases the local within Result.Stripped.  Result.Local is left as-is.
+	LowercaseLocal bool `json:"lowercase_local"`
+
+	// ExtraAllowedLocalChars contains printable ASCII characters to additionally allow, unquoted, within the local,
+	// e.g. "," for compatibility with a legacy system.  Characters that delimit the structure of an address, i.e.
+	// whitespace and any of `"().@\`, are never allowed.  Addresses accepted only due to this option are not RFC-valid.
+	ExtraAllowedLocalChars string `json:"extra_allowed_local_chars"`
+
+	// DisallowedLocalChars contains characters to reject anywhere within the local, quoted or not, with
+	// ErrDisallowedLocalCharacter, e.g. "'`" for backends that mishandle them
+	DisallowedLocalChars string `json:"disallowed_local_chars"`
+
+	// StripInvisible, if true, removes zero-width and other invisible characters before parsing, adding an
+	// ErrInvisibleCharacter warning for the first one seen.  Positions within any other diagnostics refer to the address
+	// with those characters removed.  Otherwise, they are rejected with ErrInvisibleCharacter.
+	StripInvisible bool `json:"strip_invisible"`
+
+	// DecodePercent, if true, percent-decodes input containing percent-encoded characters, e.g. "user%40example.com",
+	// before parsing, adding an ErrPercentDecoded warning.  Input that cannot be decoded is parsed as-is.
+	DecodePercent bool `json:"decode_percent"`
+
+	// DecodeHTMLEntities, if true, decodes HTML character references within input, e.g. "user&#64;example.com", before
+	// parsing, adding an ErrHTMLDecoded warning.  It is applied after DecodePercent.
+	DecodeHTMLEntities bool `json:"decode_html_entities"`
+
+	// RepairPunctuation, if true, replaces Unicode punctuation commonly introduced when addresses are pasted, e.g.
+	// curly quotes, full-width "＠", and the ideographic full stop "。", with its ASCII equivalent before parsing.  Each
+	// replacement is recorded within Result.Fixes.  It is applied after DecodePercent and DecodeHTMLEntities.
+	RepairPunctuation bool `json:"repair_punctuation"`
+
+	// SuppressionList, if defined, is used to populate Result.Suppressed
+	SuppressionList *SuppressionList `json:"-"`
+
+	// Paranoid, if true, rejects constructs with no place in an address typed into a web form with ErrUnsafeAddress,
+	// and returns only the first error seen.  See PresetParanoid.
+	Paranoid bool `json:"paranoid"`
+
+	// Engine, if defined, is used to parse addresses in place of the default StateMachineEngine
+	Engine SyntaxValidator `json:"-"`
 }
 
 type OptFunc func(*ParseOptions)
 
+// WithOptions replaces the entire ParseOptions struct with src, e.g. one returned by LoadConfig.  OptFuncs provided
+// after this one are applied on top of src.
+func WithOptions(src ParseOptions) OptFunc {
+	return func(opt *ParseOptions) {
+		*opt = src
+	}
+}
+
 func TrackCharacterPositions(opt *ParseOptions) {
 	opt.TrackCharacterPositions = true
 }
 
+// DecodePercent percent-decodes addresses taken from URLs before parsing them
+func DecodePercent(opt *ParseOptions) {
+	opt.DecodePercent = true
+}
+
+// DecodeHTMLEntities decodes HTML character references within addresses before parsing them
+func DecodeHTMLEntities(opt *ParseOptions) {
+	opt.DecodeHTMLEntities = true
+}
+
+// RepairPunctuation replaces Unicode punctuation within addresses with its ASCII equivalent before parsing them
+func RepairPunctuation(opt *ParseOptions) {
+	opt.RepairPunctuation = true
+}
+
+// StripInvisible removes zero-width and other invisible characters from addresses before parsing them
+func StripInvisible(opt *ParseOptions) {
+	opt.StripInvisible = true
+}
+
 type Result struct {
 	// Input is the verbatim provided value.
 	Input string
 
-	// Local contains the "local" portion of the email address, i.e. the part of the address prior to any sub-address
-	// and the domain.
+	// Local contains the "local" portion of the email address, i.e. the part of the address prior to the domain.
 	Local string
 
+	// Mailbox contains Local minus any sub-address.  It is equal to Local unless ParseOptions.SubAddressSeparators
+	// is defined and one was seen.
+	Mailbox string
+
+	// SubAddress contains the portion of Local following the first sub-address separator, if any.
+	SubAddress string
+
 	// Domain contains the "domain" portion of the email address, i.e. the part of the address after "@"
 	Domain string
 
+	// DomainPosition is the zero-indexed offset of the domain's first character within the input, after any decoding
+	// or repair, or 0 if there is no domain.  Comments within the domain mean it may not be contiguous from there.
+	DomainPosition int
+
 	// LiteralDomain will be true if the domain was an address-containing literal
 	LiteralDomain bool
 
-	// Comment may contain any seen comment in the address
+	// LiteralTag contains the standardized tag of a general address literal, e.g. "IPv6" for
+	// "[IPv6:2001:db8::1]".  It is empty for IPv4 literals, which are untagged.
+	LiteralTag string
+
+	// LiteralContent contains the content of an address literal, minus its brackets and any tag.
+	LiteralContent string
+
+	// LiteralAddr contains the parsed IP address of an IPv4 or IPv6 address literal
+	LiteralAddr netip.Addr
+
+	// RoleAccount will be true if the mailbox is one of ParseOptions.RoleAccounts
+	RoleAccount bool
+
+	// DomainTags contains the names of every ParseOptions.DomainLists list the domain appears in
+	DomainTags []string
+
+	// ReservedWord contains the first of ParseOptions.ReservedWords seen within the mailbox, if any
+	ReservedWord string
+
+	// Suppressed will be true if the address is likely within ParseOptions.SuppressionList
+	Suppressed bool
+
+	// PercentEncoded will be true if the input contained percent-encoded characters, e.g. "%40".  They were decoded
+	// before parsing if ParseOptions.DecodePercent is true.
+	PercentEncoded bool
+
+	// HTMLEncoded will be true if the input contained HTML character references, e.g. "&#64;".  They were decoded
+	// before parsing if ParseOptions.DecodeHTMLEntities is true.
+	HTMLEncoded bool
+
+	// Fixes contains each correction made to the input before it was parsed, in input order
+	Fixes []Fix
+
+	// Comment may contain any seen comment in the address preceding the domain
 	Comment string
 
-	// Stripped will contain the email address minus any comment
+	// Comments contains a record of each top-level comment seen in the address, in order
+	Comments []AddressComment
+
+	// TrailingComment may contain any seen comment following the domain, e.g. "work" in "user@example.com (work)"
+	TrailingComment string
+
+	// Stripped contains the smallest RFC-valid address equivalent to Input: comments are removed, quoted locals are
+	// unquoted when their content is a valid dot-atom, and otherwise only the escapes required by RFC 5321 are kept.
 	Stripped string
 
 	// Quoted returns true if this email address was quoted
 	Quoted bool
 
-	// CharacterPositions contains the complete list of unique characters seen in this address, and the list of offsets
-	// they were seen at.
-	CharacterPositions map[string][]int
+	// EmptyLocal will be true if the local is a quoted string with no content, e.g. `""@example.com`.  Such addresses
+	// are syntactically valid, but rarely deliverable.
+	EmptyLocal bool
+
+	// LocalContainsAt will be true if the local contains a quoted "@".  Such addresses are valid, but are frequently
+	// mishandled by downstream systems that split on the first "@".
+	LocalContainsAt bool
+
+	// CharacterPositions contains the complete list of unique characters seen in this address, and the offsets they
+	// were seen at.
+	CharacterPositions map[string]Positions
+
+	// CharacterPositionsTruncated will be true if ParseOptions.MaxPositionRuns was reached, in which case
+	// CharacterPositions is incomplete
+	CharacterPositionsTruncated bool
+
+	// Flags consolidates the risk-relevant signals seen in this address
+	Flags Flags
+
+	// Warnings contains diagnostics that were configured to not fail validation
+	Warnings []ParseError
 
 	// Err contains any / all errors seen during the validation of the address
-	Err error
+	Err error `json:"-"`
 }
 
 func BuildResult(email string, opts ...OptFunc) (Result, error) {
-	const (
-		strstr = "%s%s"
-	)
-
 	var (
 		parseOpts ParseOptions
 		chr       string
@@ -80,12 +299,26 @@ func BuildResult(email string, opts ...OptFunc) (Result, error) {
 		err       error
 		errs      []error
 
-		inputLen = len(email)
+		// the local and domain are accumulated separately from res, as they are rarely contiguous within the input
+		local  = make([]byte, 0, len(email))
+		domain = make([]byte, 0, len(email))
+
+		inputLen       = len(email)
+		positionBudget int
+		folds          foldScanner
+
+		// set once whitespace or a comment follows content within the local, and cleared by a dot
+		localSeparated bool
+
+		// offset of the most recent unquoted period within the local, as comments may separate it from the "@"
+		localDot = -1
 
 		inLocal    = true
 		localDone  = false
 		inQuote    = false
 		inComment  = false
+		depth      = 0
+		escaped    = false
 		inDomain   = false
 		domainDone = false
 
@@ -100,17 +333,88 @@ func BuildResult(email string, opts ...OptFunc) (Result, error) {
 		fn(&parseOpts)
 	}
 
+	// addresses taken from URLs may need decoding before anything else can be checked
+	res.PercentEncoded = hasPercentEscape(email)
+	if res.PercentEncoded && parseOpts.DecodePercent {
+		var pe *ParseError
+		if email, pe = decodePercent(email); pe != nil {
+			res.Warnings = append(res.Warnings, *pe)
+			inputLen = len(email)
+		}
+	}
+
+	// addresses pasted from HTML source may contain character references, e.g. "&#64;"
+	res.HTMLEncoded = hasHTMLEntity(email)
+	if res.HTMLEncoded && parseOpts.DecodeHTMLEntities {
+		var pe *ParseError
+		if email, pe = decodeHTMLEntities(email); pe != nil {
+			res.Warnings = append(res.Warnings, *pe)
+			inputLen = len(email)
+		}
+	}
+
+	// word processors and input methods substitute punctuation that has no place in an address
+	if parseOpts.RepairPunctuation {
+		var fixes []Fix
+		if email, fixes = repairPunctuation(email); fixes != nil {
+			res.Fixes = append(res.Fixes, fixes...)
+			inputLen = len(email)
+		}
+	}
+
+	// text direction overrides are only ever seen in spoofing attempts
+	if pe := checkBidi(email); pe != nil {
+		res.Err = localize(*pe, &parseOpts)
+		return *res, res.Err
+	}
+
+	// zero-width characters are typically carried along with copied addresses
+	if pe := checkInvisible(email); pe != nil {
+		if !parseOpts.StripInvisible {
+			res.Err = localize(*pe, &parseOpts)
+			return *res, res.Err
+		}
+		res.Warnings = append(res.Warnings, *pe)
+		email = stripInvisible(email)
+		inputLen = len(email)
+	}
+
+	// there is nothing to parse in empty or whitespace-only input
+	if strings.TrimSpace(email) == "" {
+		res.Err = localize(newParseError(ErrEmptyInput, -1, "", ""), &parseOpts)
+		return *res, res.Err
+	}
+
+	// screen untrusted input before any parsing
+	if parseOpts.Paranoid {
+		if pe := checkParanoid(email); pe != nil {
+			res.Err = localize(*pe, &parseOpts)
+			return *res, res.Err
+		}
+	}
+
+	// delegate to alternate engines, which must neither decode nor repair the input a second time
+	if parseOpts.Engine != nil {
+		engineOpts := parseOpts
+		engineOpts.DecodePercent, engineOpts.DecodeHTMLEntities, engineOpts.RepairPunctuation = false, false, false
+		engineRes, engineErr := parseOpts.Engine.Parse(email, engineOpts)
+		return withPreParse(engineRes, *res), engineErr
+	}
+
 	// if we need to track character positions, do so.
 	if parseOpts.TrackCharacterPositions {
-		res.CharacterPositions = make(map[string][]int)
+		res.CharacterPositions = make(map[string]Positions)
+		positionBudget = parseOpts.maxPositionRuns()
 	}
 
+	folds.email = email
+
 	// iterate through provided value and do stuff.
 	for i := 0; i < inputLen; i++ {
 
 		// get current character and decimal in ascii table
 		dec = email[i]
-		chr = string(dec)
+		chr = email[i : i+1]
 
 		// if we're beyond the first character, localize previous value
 		if i > 0 {
@@ -119,17 +423,26 @@ func BuildResult(email string, opts ...OptFunc) (Result, error) {
 		// if we've not reached the end, find the next character
 		if i+1 < inputLen {
 			nextDec = email[i+1]
+		} else {
+			nextDec = 0
 		}
 
+		// localize comment state, so delimiters may be excluded from the recorded comment text
+		wasInComment := inComment
+
 		// reset error
 		err = nil
 
 		// update char map, if configured to do so.
 		if parseOpts.TrackCharacterPositions {
-			if _, ok := res.CharacterPositions[chr]; !ok {
-				res.CharacterPositions[chr] = make([]int, 0)
-			}
-			res.CharacterPositions[chr] = append(res.CharacterPositions[chr], i)
+			trackPosition(res, chr, i, &positionBudget)
+		}
+
+		// the character following a backslash in a comment is taken literally
+		if inComment && escaped {
+			escaped = false
+			appendComment(res, chr, localDone && len(domain) > 0)
+			continue
 		}
 
 		// make some decisions
@@ -147,17 +460,23 @@ func BuildResult(email string, opts ...OptFunc) (Result, error) {
 			err = fmt.Errorf("%w: position %d", ErrUnexpectedNonGraphicCharacter, i)
 
 		case 9: // horizontal tab
-			// horizontal tab characters may only exist in the local portion of a quoted address
-			if inDomain {
+			// horizontal tab characters may only exist in the local portion of a quoted address, or as folding
+			// whitespace around a comment
+			if inComment || folds.foldsAroundComment(i) {
+				// allowed
+			} else if inDomain {
 				err = fmt.Errorf("%w: horizontal tab at position %d in domain", ErrUnexpectedCharacter, i)
 			} else if !inQuote {
 				err = fmt.Errorf("%w: horizontal tab at position %d in local", ErrInvalidUnquotedSequence, i)
 			}
 
 		case 10, // LF
-			11, // vertical tab
+			13: // CR
+			// line breaks are never valid, and are a common means of smuggling additional headers into messages
+			err = fmt.Errorf("%w: %q at position %d", ErrPossibleHeaderInjection, chr, i)
+
+		case 11, // vertical tab
 			12, // form feed
-			13, // CR
 			14, // shift out
 			15, // shift in
 			16, // data link escape
@@ -179,7 +498,9 @@ func BuildResult(email string, opts ...OptFunc) (Result, error) {
 			err = fmt.Errorf("%w: position %d", ErrUnexpectedNonGraphicCharacter, i)
 
 		case 32: // space
-			if inDomain {
+			if inComment || folds.foldsAroundComment(i) {
+				// allowed
+			} else if inDomain {
 				err = fmt.Errorf("%w: space at poosition %d in domain", ErrUnexpectedCharacter, i)
 			} else if !inQuote && !inComment {
 				err = fmt.Errorf("%w: space at position %d in local", ErrInvalidUnquotedSequence, i)
@@ -195,12 +516,25 @@ func BuildResult(email string, opts ...OptFunc) (Result, error) {
 			if inLocal {
 				if inQuote {
 					// determine if this is an escaped quote
-					if prevDec != 92 {
+					if escaped {
+						escaped = false
+					} else {
 						//  if not escaped, mark sequence as ended and flip result quoted flag
 						inQuote = false
 						res.Quoted = true
+
+						// a quoted section must be followed by a period, the domain, a comment, or nothing.
+						switch nextDec {
+						case 0, 40, 46, 64:
+						default:
+							err = fmt.Errorf("%w: double quote at position %d must be followed by \".\" or \"@\"", ErrUnexpectedCharacter, i)
+						}
 					}
 				} else {
+					// a quoted section must begin the local or immediately follow a period.
+					if l := len(local); l > 0 && local[l-1] != 46 {
+						err = fmt.Errorf("%w: double quote at position %d must begin the local or follow \".\"", ErrUnexpectedCharacter, i)
+					}
 					inQuote = true
 				}
 			} else {
@@ -218,20 +552,52 @@ func BuildResult(email string, opts ...OptFunc) (Result, error) {
 
 		case 40: // (
 			// open parens are only allowed in quoted locals or as a comment opening marker
-			if inDomain {
+			if inComment {
+				// comments may be nested, up to the configured depth
+				depth++
+				if limit := parseOpts.maxCommentDepth(); depth > limit {
+					err = fmt.Errorf("%w: %q at position %d exceeds maximum comment depth of %d", ErrUnexpectedCharacter, chr, i, limit)
+				}
+				if c := &res.Comments[len(res.Comments)-1]; depth > c.Depth {
+					c.Depth = depth
+				}
+			} else if inDomain && res.LiteralDomain {
+				// not allowed within a domain literal
 				err = fmt.Errorf("%w: %q at position %d in domain", ErrUnexpectedCharacter, chr, i)
-			} else if inComment {
-				err = fmt.Errorf("%w: %q at position %d in commment", ErrUnexpectedCharacter, chr, i)
 			} else if !inQuote {
+				if inLocal && parseOpts.StrictDotAtom {
+					err = fmt.Errorf("%w: %q at position %d begins comment in local", ErrUnexpectedCharacter, chr, i)
+				}
 				inComment = true
+				depth = 1
+				res.Comments = append(res.Comments, AddressComment{
+					Position:  i,
+					Depth:     1,
+					Placement: commentPlacement(inLocal, len(local), len(domain)),
+				})
+				// comments on the domain side suspend domain parsing until closed
+				inDomain = false
 			}
 
 		case 41: // )
 			// close parens are only allowed in quoted locals or as comment closing marker
-			if inDomain {
-				err = fmt.Errorf("%w: %q at position %d in domain", ErrUnexpectedCharacter, chr, i)
+			if inComment && depth > 1 {
+				// closes a nested comment
+				depth--
 			} else if inComment {
 				inComment = false
+				depth = 0
+				if !inLocal && !domainDone {
+					if len(domain) > 0 {
+						// a comment following the domain ends the address
+						domainDone = true
+					} else {
+						// a comment preceding the domain
+						inDomain = true
+					}
+				}
+			} else if inDomain {
+				err = fmt.Errorf("%w: %q at position %d in domain", ErrUnexpectedCharacter, chr, i)
 			} else if !inQuote {
 				err = fmt.Errorf("%w: %q at position %d in local", ErrUnexpectedCharacter, chr, i)
 			}
@@ -260,9 +626,16 @@ func BuildResult(email string, opts ...OptFunc) (Result, error) {
 			}
 
 		case 45: // -
-			// hyphen is only allowed in local, and may mark start of sub address
+			// hyphen may mark start of sub address in local, and is allowed within (but may not begin or end) a domain
+			// label
 			if inDomain {
-				err = fmt.Errorf("%w: %q at position %d in domain", ErrUnexpectedCharacter, chr, i)
+				if !res.LiteralDomain {
+					if l := len(domain); l == 0 || domain[l-1] == 46 {
+						err = fmt.Errorf("%w: %q at position %d begins domain label", ErrUnexpectedCharacter, chr, i)
+					} else if nextDec == 0 || nextDec == 9 || nextDec == 32 || nextDec == 40 || nextDec == 46 {
+						err = fmt.Errorf("%w: %q at position %d ends domain label", ErrUnexpectedCharacter, chr, i)
+					}
+				}
 			} else if inComment {
 				err = fmt.Errorf("%w: %q at position %d in comment", ErrUnexpectedCharacter, chr, i)
 			}
@@ -283,6 +656,28 @@ func BuildResult(email string, opts ...OptFunc) (Result, error) {
 			} else if inComment {
 				// not allowed in comments, maybe?
 				err = fmt.Errorf("%w: %q at position %d in comment", ErrUnexpectedCharacter, chr, i)
+			} else if inLocal && !inQuote && len(local) == 0 {
+				// a leading comment does not make room for a period to begin the local
+				err = fmt.Errorf("%w: %q at position %d in local", ErrUnexpectedCharacter, chr, i)
+			} else if inLocal && !inQuote && local[len(local)-1] == 46 {
+				// nor does a comment between periods
+				err = fmt.Errorf("%w: %q at position %d in local", ErrInvalidUnquotedSequence, chr, i)
+			} else if !inQuote && (parseOpts.behaviorVersion() >= BehaviorVersion2 || parseOpts.StrictDotAtom) {
+				// dot-atoms may not contain empty atoms
+				if inDomain {
+					if res.LiteralDomain {
+						// allowed within literals
+					} else if len(domain) == 0 {
+						err = fmt.Errorf("%w: %q at position %d begins domain", ErrUnexpectedCharacter, chr, i)
+					} else if nextDec == 0 || nextDec == 9 || nextDec == 32 || nextDec == 40 {
+						err = fmt.Errorf("%w: %q at position %d ends domain", ErrUnexpectedCharacter, chr, i)
+					}
+				} else if inLocal && nextDec == 0 {
+					err = fmt.Errorf("%w: %q at position %d ends local", ErrUnexpectedCharacter, chr, i)
+				}
+			}
+			if inLocal && !inQuote && !inComment {
+				localDot = i
 			}
 
 		case 47: // /
@@ -350,6 +745,15 @@ func BuildResult(email string, opts ...OptFunc) (Result, error) {
 				// if not in a quote sequence, end local sequence
 				inLocal = false
 				inDomain = true
+
+				// dot-atoms may not end with an empty atom, even when a comment separates the period from the "@"
+				if l := len(local); l > 0 && local[l-1] == 46 && localDot >= 0 &&
+					(parseOpts.behaviorVersion() >= BehaviorVersion2 || parseOpts.StrictDotAtom) {
+					err = fmt.Errorf("%w: %q at position %d ends local", ErrUnexpectedCharacter, ".", localDot)
+				}
+			} else {
+				// quoted "@" is part of the local
+				res.LocalContainsAt = true
 			}
 
 		case 65, // A
@@ -383,7 +787,7 @@ func BuildResult(email string, opts ...OptFunc) (Result, error) {
 
 		case 91: // [
 			if inDomain {
-				if len(res.Domain) == 0 {
+				if len(domain) == 0 {
 					// mark beginning of literal domain sequence
 					res.LiteralDomain = true
 				} else {
@@ -402,15 +806,26 @@ func BuildResult(email string, opts ...OptFunc) (Result, error) {
 			if inDomain {
 				err = fmt.Errorf("%w: %q at position %d in domain", ErrUnexpectedCharacter, chr, i)
 			} else if inComment {
-				err = fmt.Errorf("%w: %q at position %d in comment", ErrUnexpectedCharacter, chr, i)
+				// as of BehaviorVersion3, comments may contain quoted-pairs, e.g. "\)"
+				if parseOpts.behaviorVersion() < BehaviorVersion3 {
+					err = fmt.Errorf("%w: %q at position %d in comment", ErrUnexpectedCharacter, chr, i)
+				} else if nextDec == 9 || (nextDec >= 32 && nextDec <= 126) {
+					escaped = true
+				} else {
+					err = fmt.Errorf("%w: %q at position %d in comment", ErrUnexpectedCharacter, chr, i)
+				}
 			} else if !inQuote {
 				err = fmt.Errorf("%w: %q at position %d in local", ErrInvalidUnquotedSequence, chr, i)
+			} else if escaped {
+				// this backslash was itself escaped
+				escaped = false
 			} else {
 				switch nextDec {
 				case 34, // "
 					92: // \
 
 					// these characters may be escaped through a backslash in a quoted sequence
+					escaped = true
 
 				default:
 					err = fmt.Errorf("%w: %q at position %d in local", ErrUnexpectedCharacter, chr, i)
@@ -483,29 +898,61 @@ func BuildResult(email string, opts ...OptFunc) (Result, error) {
 			err = fmt.Errorf("%w: position %d", ErrUnexpectedCharacter, i)
 		}
 
+		// within a domain literal, only RFC 5321 dtext is allowed.  this supersedes any domain-specific rules above.
+		if inDomain && res.LiteralDomain && len(domain) > 0 {
+			err = checkDtext(dec, i)
+		}
+
+		// apply operator adjustments to the local character set
+		if inLocal && !inComment && !wasInComment {
+			if strings.IndexByte(parseOpts.DisallowedLocalChars, dec) != -1 {
+				err = fmt.Errorf("%w: %q at position %d", ErrDisallowedLocalCharacter, chr, i)
+			} else if err != nil && !inQuote && isExtraAllowedLocalChar(parseOpts.ExtraAllowedLocalChars, dec) {
+				err = nil
+			}
+		}
+
 		// if error, add to error list.
 		if err != nil {
-			errs = append(errs, err)
+			errs = append(errs, newParseError(err, i, chr, currentSegment(inComment, inDomain || localDone)))
 		}
 
 		// determine what to do with character
 
-		if !localDone {
+		if (dec == 32 || dec == 9) && !inQuote && !inComment {
+			// folding whitespace around comments is not part of the address.  whitespace that is not folding has already
+			// been reported.
+			localSeparated = localSeparated || (err == nil && !localDone && !inDomain && len(local) > 0)
+		} else if !localDone {
 			// handle "local" portion
 
-			if inComment {
-				res.Comment = fmt.Sprintf(strstr, res.Comment, chr)
+			if inComment || wasInComment {
+				// record comment text, minus its delimiters
+				if inComment && wasInComment {
+					appendComment(res, chr, false)
+				}
+				localSeparated = localSeparated || len(local) > 0
 			} else if inDomain {
 				// handle transition to domain
 				localDone = true
 
 				if dec != 64 {
-					res.Domain = fmt.Sprintf(strstr, res.Domain, chr)
+					res.DomainPosition = i
+					domain = append(domain, dec)
 				}
-				res.Stripped = fmt.Sprintf(strstr, res.Stripped, chr)
 			} else {
-				res.Local = fmt.Sprintf(strstr, res.Local, chr)
-				res.Stripped = fmt.Sprintf(strstr, res.Stripped, chr)
+				// whitespace and comments may only surround atoms, never join them
+				if localSeparated && dec != 46 && local[len(local)-1] != 46 {
+					err = fmt.Errorf("%w: atoms separated without a dot at position %d in local", ErrInvalidUnquotedSequence, i)
+					errs = append(errs, newParseError(err, i, chr, SegmentLocal))
+				}
+				localSeparated = false
+				local = append(local, dec)
+			}
+		} else if inComment || wasInComment {
+			// handle comments on the domain side, minus their delimiters
+			if inComment && wasInComment {
+				appendComment(res, chr, len(domain) > 0)
 			}
 		} else if !domainDone {
 			// handle "domain" portion
@@ -514,26 +961,138 @@ func BuildResult(email string, opts ...OptFunc) (Result, error) {
 				domainDone = true
 			}
 			if dec != 64 {
-				res.Domain = fmt.Sprintf(strstr, res.Domain, chr)
+				if len(domain) == 0 {
+					res.DomainPosition = i
+				}
+				domain = append(domain, dec)
 			}
-			res.Stripped = fmt.Sprintf(strstr, res.Stripped, chr)
 		} else {
-			errs = append(errs, fmt.Errorf("%w: %q at position %d beyond domain", ErrUnexpectedCharactersAfterDomain, chr, i))
+			err = fmt.Errorf("%w: %q at position %d beyond domain", ErrUnexpectedCharactersAfterDomain, chr, i)
+			errs = append(errs, newParseError(err, i, chr, SegmentDomain))
 		}
 	}
 
+	res.Local = string(local)
+	res.Domain = string(domain)
+
 	// do some final checks
+	if inQuote {
+		errs = append(errs, newParseError(fmt.Errorf("%w: unterminated quoted string", ErrUnexpectedCharacter), -1, "", SegmentLocal))
+	}
+	if inComment {
+		errs = append(errs, newParseError(fmt.Errorf("%w: unterminated comment", ErrUnexpectedCharacter), -1, "", SegmentComment))
+	}
 	if l := len(res.Local); l > LocalPartMaxLength {
-		errs = append(errs, fmt.Errorf("%w: %d", ErrLocalPartTooLong, l))
+		errs = append(errs, newParseError(fmt.Errorf("%w: %d", ErrLocalPartTooLong, l), -1, "", SegmentLocal))
 	} else if l == 0 {
-		errs = append(errs, ErrZeroLengthLocalPart)
+		errs = append(errs, newParseError(ErrZeroLengthLocalPart, -1, "", SegmentLocal))
 	}
 	if l := len(res.Domain); l > DomainMaxLength {
-		errs = append(errs, fmt.Errorf("%w: %d", ErrDomainTooLong, l))
+		errs = append(errs, newParseError(fmt.Errorf("%w: %d", ErrDomainTooLong, l), -1, "", SegmentDomain))
 	} else if l == 0 {
-		errs = append(errs, ErrZeroLengthDomain)
+		errs = append(errs, newParseError(ErrZeroLengthDomain, -1, "", SegmentDomain))
+	}
+
+	// build minimal equivalent address
+	res.Stripped = minimalLocal(res.Local, res.Quoted)
+	if parseOpts.LowercaseLocal {
+		res.Stripped = strings.ToLower(res.Stripped)
+	}
+	if localDone {
+		res.Stripped = fmt.Sprintf("%s@%s", res.Stripped, res.Domain)
+	}
+
+	// validate address literals
+	if res.LiteralDomain {
+		errs = append(errs, checkLiteral(res, &parseOpts)...)
+	}
+
+	// flag quoted locals with no content
+	if res.Quoted && unquoteLocal(res.Local) == "" {
+		res.EmptyLocal = true
+		if parseOpts.RejectEmptyLocal {
+			err = fmt.Errorf("%w: empty quoted string", ErrZeroLengthLocalPart)
+			errs = append(errs, newParseError(err, -1, "", SegmentLocal))
+		}
+	}
+
+	// require a single dot-string or quoted-string
+	if parseOpts.StrictDotAtom && res.Quoted && !isQuotedString(res.Local) {
+		err = fmt.Errorf("%w: quoted and unquoted sections mixed in local %q", ErrInvalidUnquotedSequence, res.Local)
+		errs = append(errs, newParseError(err, -1, "", SegmentLocal))
+	}
+
+	// split out sub-address and apply mailbox rules
+	errs = append(errs, checkMailbox(res, &parseOpts)...)
+	errs = append(errs, checkRole(res, &parseOpts)...)
+	checkReserved(res, &parseOpts)
+
+	// advise of case-sensitive locals
+	if parseOpts.WarnUppercaseLocal && strings.ToLower(res.Local) != res.Local {
+		res.Warnings = append(res.Warnings, newParseError(ErrUppercaseLocal, -1, "", SegmentLocal))
+	}
+
+	// categorize domain
+	if parseOpts.DomainLists != nil && res.Domain != "" && !res.LiteralDomain {
+		res.DomainTags = parseOpts.DomainLists.Tags(res.Domain)
+	}
+	checkSuppressed(res, &parseOpts)
+
+	res.Flags = flagsOf(res)
+
+	// paranoid callers want a single, clear reason for rejection
+	if parseOpts.Paranoid && len(errs) > 1 {
+		errs = errs[:1]
+	}
+
+	// attach human-readable messages
+	localizeErrors(errs, &parseOpts)
+	for i := range res.Warnings {
+		res.Warnings[i] = localize(res.Warnings[i], &parseOpts)
 	}
 
 	// return res and any errors seen.
-	return *res, errors.Join(errs...)
+	res.Err = errors.Join(errs...)
+	return *res, res.Err
+}
+
+// isExtraAllowedLocalChar returns true if c is within extra and may be allowed within an unquoted local
+func isExtraAllowedLocalChar(extra string, c byte) bool {
+	return c > 32 && c < 127 && strings.IndexByte(`"().@\`, c) == -1 && strings.IndexByte(extra, c) != -1
+}
+
+// currentSegment returns the Segment the parser is in given its current state
+func currentSegment(inComment, inDomain bool) Segment {
+	if inComment {
+		return SegmentComment
+	} else if inDomain {
+		return SegmentDomain
+	}
+	return SegmentLocal
+}
+
+// foldScanner determines whether whitespace characters are folding whitespace around a comment.  Each run of
+// whitespace is scanned once, and the result reused for the rest of the run, so long runs remain linear.
+type foldScanner struct {
+	email string
+	end   int
+	folds bool
+}
+
+// foldsAroundComment returns true if the whitespace character at position i is part of a run of whitespace
+// immediately preceding the opening or following the closing of a comment.  Positions must be visited in order.
+func (f *foldScanner) foldsAroundComment(i int) bool {
+	if i < f.end {
+		return f.folds
+	}
+	start, end := i, i+1
+	for start > 0 && (f.email[start-1] == 32 || f.email[start-1] == 9) {
+		start--
+	}
+	for end < len(f.email) && (f.email[end] == 32 || f.email[end] == 9) {
+		end++
+	}
+	f.end = end
+	f.folds = (start > 0 && f.email[start-1] == 41) || (end < len(f.email) && f.email[end] == 40)
+	return f.folds
 }
@@ -2,7 +2,10 @@ package emailvalidator_test
 
 import (
 	"errors"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	emailvalidator "github.com/dcarbone/go-email-validator"
 )
@@ -156,3 +159,838 @@ func TestBuildResult(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildResult_Stripped(t *testing.T) {
+	steps := []struct {
+		label    string
+		input    string
+		stripped string
+		local    string
+		comment  string
+	}{
+		{
+			label:    "simple",
+			input:    "simple@example.com",
+			stripped: "simple@example.com",
+			local:    "simple",
+		},
+		{
+			label:    "needlessly-quoted",
+			input:    `"john.doe"@example.com`,
+			stripped: "john.doe@example.com",
+			local:    `"john.doe"`,
+		},
+		{
+			label:    "quoted-dot-segments",
+			input:    `"john".doe@example.com`,
+			stripped: "john.doe@example.com",
+			local:    `"john".doe`,
+		},
+		{
+			label:    "quoted-double-dot",
+			input:    `"john..doe"@example.org`,
+			stripped: `"john..doe"@example.org`,
+			local:    `"john..doe"`,
+		},
+		{
+			label:    "quoted-space",
+			input:    `" "@example.org`,
+			stripped: `" "@example.org`,
+			local:    `" "`,
+		},
+		{
+			label:    "quoted-segments-merged",
+			input:    `"a b"."c d"@example.org`,
+			stripped: `"a b.c d"@example.org`,
+			local:    `"a b"."c d"`,
+		},
+		{
+			label:    "escapes-kept",
+			input:    `"a\"b\\c"@example.org`,
+			stripped: `"a\"b\\c"@example.org`,
+			local:    `"a\"b\\c"`,
+		},
+		{
+			label:    "leading-comment",
+			input:    "(comment)john@example.com",
+			stripped: "john@example.com",
+			local:    "john",
+			comment:  "comment",
+		},
+		{
+			label:    "trailing-local-comment",
+			input:    "john(comment)@example.com",
+			stripped: "john@example.com",
+			local:    "john",
+			comment:  "comment",
+		},
+		{
+			label:    "literal",
+			input:    "postmaster@[123.123.123.123]",
+			stripped: "postmaster@[123.123.123.123]",
+			local:    "postmaster",
+		},
+	}
+
+	for _, step := range steps {
+		t.Run(step.label, func(t *testing.T) {
+			res, err := emailvalidator.BuildResult(step.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if res.Stripped != step.stripped {
+				t.Errorf("expected Stripped %q, saw %q", step.stripped, res.Stripped)
+			}
+			if res.Local != step.local {
+				t.Errorf("expected Local %q, saw %q", step.local, res.Local)
+			}
+			if res.Comment != step.comment {
+				t.Errorf("expected Comment %q, saw %q", step.comment, res.Comment)
+			}
+		})
+	}
+}
+
+func TestBuildResult_DomainComments(t *testing.T) {
+	steps := []struct {
+		label    string
+		input    string
+		domain   string
+		comment  string
+		trailing string
+		err      error
+	}{
+		{
+			label:    "trailing",
+			input:    "user@example.com (work)",
+			domain:   "example.com",
+			trailing: "work",
+		},
+		{
+			label:    "trailing-no-space",
+			input:    "user@example.com(work)",
+			domain:   "example.com",
+			trailing: "work",
+		},
+		{
+			label:    "trailing-after-literal",
+			input:    "user@[127.0.0.1] (loopback)",
+			domain:   "[127.0.0.1]",
+			trailing: "loopback",
+		},
+		{
+			label:   "preceding",
+			input:   "user@(work)example.com",
+			domain:  "example.com",
+			comment: "work",
+		},
+		{
+			label: "text-after-trailing",
+			input: "user@example.com (work) extra",
+			err:   emailvalidator.ErrUnexpectedCharactersAfterDomain,
+		},
+		{
+			label: "unterminated",
+			input: "user@example.com (work",
+			err:   emailvalidator.ErrUnexpectedCharacter,
+		},
+		{
+			label: "space-without-comment",
+			input: "user@example.com extra",
+			err:   emailvalidator.ErrUnexpectedCharacter,
+		},
+		{
+			label: "paren-in-literal",
+			input: "user@[127.0.0.1(x)]",
+			err:   emailvalidator.ErrInvalidAddressLiteral,
+		},
+	}
+
+	for _, step := range steps {
+		t.Run(step.label, func(t *testing.T) {
+			res, err := emailvalidator.BuildResult(step.input)
+			if step.err != nil {
+				if !errors.Is(err, step.err) {
+					t.Errorf("expected %v, saw %v", step.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if res.Domain != step.domain {
+				t.Errorf("expected Domain %q, saw %q", step.domain, res.Domain)
+			}
+			if res.Comment != step.comment {
+				t.Errorf("expected Comment %q, saw %q", step.comment, res.Comment)
+			}
+			if res.TrailingComment != step.trailing {
+				t.Errorf("expected TrailingComment %q, saw %q", step.trailing, res.TrailingComment)
+			}
+			if res.Stripped != "user@"+step.domain {
+				t.Errorf("expected Stripped %q, saw %q", "user@"+step.domain, res.Stripped)
+			}
+		})
+	}
+}
+
+func TestBuildResult_QuotedAt(t *testing.T) {
+	steps := []struct {
+		label      string
+		input      string
+		local      string
+		domain     string
+		containsAt bool
+		err        error
+	}{
+		{
+			label:      "quoted-at",
+			input:      `"very@strange"@example.com`,
+			local:      `"very@strange"`,
+			domain:     "example.com",
+			containsAt: true,
+		},
+		{
+			label:      "only-at",
+			input:      `"@"@example.com`,
+			local:      `"@"`,
+			domain:     "example.com",
+			containsAt: true,
+		},
+		{
+			label:      "dotted-quoted-at",
+			input:      `first."a@b".last@example.com`,
+			local:      `first."a@b".last`,
+			domain:     "example.com",
+			containsAt: true,
+		},
+		{
+			label:      "escaped-quote-before-at",
+			input:      `"a\"@"@example.com`,
+			local:      `"a\"@"`,
+			domain:     "example.com",
+			containsAt: true,
+		},
+		{
+			label:  "no-quoted-at",
+			input:  `"very.strange"@example.com`,
+			local:  `"very.strange"`,
+			domain: "example.com",
+		},
+		{
+			label: "unquoted-at-after-quoted",
+			input: `"a@b"@c@example.com`,
+			err:   emailvalidator.ErrUnexpectedCharacter,
+		},
+		{
+			label: "unterminated-quote-swallows-at",
+			input: `"very@example.com`,
+			err:   emailvalidator.ErrZeroLengthDomain,
+		},
+	}
+
+	for _, step := range steps {
+		t.Run(step.label, func(t *testing.T) {
+			res, err := emailvalidator.BuildResult(step.input)
+			if step.err != nil {
+				if !errors.Is(err, step.err) {
+					t.Errorf("expected %v, saw %v", step.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if res.Local != step.local || res.Domain != step.domain {
+				t.Errorf("expected %q / %q, saw %q / %q", step.local, step.domain, res.Local, res.Domain)
+			}
+			if res.LocalContainsAt != step.containsAt {
+				t.Errorf("expected LocalContainsAt %t, saw %t", step.containsAt, res.LocalContainsAt)
+			}
+		})
+	}
+}
+
+func TestBuildResult_EmptyLocal(t *testing.T) {
+	steps := []struct {
+		label string
+		input string
+		empty bool
+	}{
+		{label: "empty", input: `""@example.com`, empty: true},
+		{label: "quoted-space", input: `" "@example.com`},
+		{label: "unquoted", input: "user@example.com"},
+	}
+
+	for _, step := range steps {
+		t.Run(step.label, func(t *testing.T) {
+			res, err := emailvalidator.BuildResult(step.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if res.EmptyLocal != step.empty || res.Flags.Has(emailvalidator.FlagEmptyLocal) != step.empty {
+				t.Errorf("expected EmptyLocal %t, saw %t with flags %q", step.empty, res.EmptyLocal, res.Flags)
+			}
+
+			_, err = emailvalidator.BuildResult(step.input, func(opt *emailvalidator.ParseOptions) { opt.RejectEmptyLocal = true })
+			if step.empty && !errors.Is(err, emailvalidator.ErrZeroLengthLocalPart) {
+				t.Errorf("expected ErrZeroLengthLocalPart, saw %v", err)
+			} else if !step.empty && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestBuildResult_NestedComments(t *testing.T) {
+	steps := []struct {
+		label    string
+		input    string
+		opts     []emailvalidator.OptFunc
+		comments []emailvalidator.AddressComment
+		err      error
+	}{
+		{
+			label:    "flat",
+			input:    "(a)user@example.com",
+			comments: []emailvalidator.AddressComment{{Text: "a", Depth: 1, Position: 0, Placement: emailvalidator.CommentBeforeLocal}},
+		},
+		{
+			label: "nested-default",
+			input: "((a)b)user@example.com",
+			err:   emailvalidator.ErrUnexpectedCharacter,
+		},
+		{
+			label:    "nested-latest",
+			input:    "((a)b)user@example.com",
+			opts:     []emailvalidator.OptFunc{emailvalidator.WithBehaviorVersion(emailvalidator.LatestBehaviorVersion)},
+			comments: []emailvalidator.AddressComment{{Text: "(a)b", Depth: 2, Position: 0, Placement: emailvalidator.CommentBeforeLocal}},
+		},
+		{
+			label: "nested-configured",
+			input: "user@example.com (work (a (b)))",
+			opts:  []emailvalidator.OptFunc{func(opt *emailvalidator.ParseOptions) { opt.MaxCommentDepth = 3 }},
+			comments: []emailvalidator.AddressComment{
+				{Text: "work (a (b))", Depth: 3, Position: 17, Placement: emailvalidator.CommentAfterDomain},
+			},
+		},
+		{
+			label: "too-deep",
+			input: "user@example.com (work (a (b)))",
+			opts:  []emailvalidator.OptFunc{func(opt *emailvalidator.ParseOptions) { opt.MaxCommentDepth = 2 }},
+			err:   emailvalidator.ErrUnexpectedCharacter,
+		},
+		{
+			label: "adjacent",
+			input: "(a)(b)user(c)@example.com",
+			comments: []emailvalidator.AddressComment{
+				{Text: "a", Depth: 1, Position: 0, Placement: emailvalidator.CommentBeforeLocal},
+				{Text: "b", Depth: 1, Position: 3, Placement: emailvalidator.CommentBeforeLocal},
+				{Text: "c", Depth: 1, Position: 10, Placement: emailvalidator.CommentAfterLocal},
+			},
+		},
+		{
+			label: "unbalanced",
+			input: "((a)user@example.com",
+			opts:  []emailvalidator.OptFunc{emailvalidator.WithBehaviorVersion(emailvalidator.LatestBehaviorVersion)},
+			err:   emailvalidator.ErrUnexpectedCharacter,
+		},
+	}
+
+	for _, step := range steps {
+		t.Run(step.label, func(t *testing.T) {
+			res, err := emailvalidator.BuildResult(step.input, step.opts...)
+			if step.err != nil {
+				if !errors.Is(err, step.err) {
+					t.Errorf("expected %v, saw %v", step.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(res.Comments, step.comments) {
+				t.Errorf("expected comments %+v, saw %+v", step.comments, res.Comments)
+			}
+			if res.Local != "user" || res.Domain != "example.com" {
+				t.Errorf("unexpected result: %+v", res)
+			}
+		})
+	}
+}
+
+func TestBuildResult_CommentQuotedPairs(t *testing.T) {
+	latest := emailvalidator.WithBehaviorVersion(emailvalidator.LatestBehaviorVersion)
+
+	steps := []struct {
+		label    string
+		input    string
+		comment  string
+		trailing string
+		err      error
+	}{
+		{label: "escaped-close", input: `(a\)b)user@example.com`, comment: `a\)b`},
+		{label: "escaped-open", input: `user@example.com (a\(b)`, trailing: `a\(b`},
+		{label: "escaped-backslash", input: `user(a\\)@example.com`, comment: `a\\`},
+		{label: "escaped-space", input: `user@example.com (a\ b)`, trailing: `a\ b`},
+		{label: "escaped-at", input: `(a\@b)user@example.com`, comment: `a\@b`},
+		{label: "trailing-backslash", input: `user@example.com (a\`, err: emailvalidator.ErrUnexpectedCharacter},
+		{label: "escaped-control", input: "(a\\\x01)user@example.com", err: emailvalidator.ErrUnexpectedCharacter},
+	}
+
+	for _, step := range steps {
+		t.Run(step.label, func(t *testing.T) {
+			if _, err := emailvalidator.BuildResult(step.input); err == nil {
+				t.Error("expected error without BehaviorVersion3")
+			}
+
+			res, err := emailvalidator.BuildResult(step.input, latest)
+			if step.err != nil {
+				if !errors.Is(err, step.err) {
+					t.Errorf("expected %v, saw %v", step.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if res.Comment != step.comment || res.TrailingComment != step.trailing {
+				t.Errorf("expected comments %q and %q, saw %q and %q", step.comment, step.trailing, res.Comment, res.TrailingComment)
+			}
+			if res.Stripped != "user@example.com" {
+				t.Errorf("unexpected Stripped %q", res.Stripped)
+			}
+		})
+	}
+}
+
+func TestBuildResult_CommentPlacement(t *testing.T) {
+	res, err := emailvalidator.BuildResult("(a)user(b)@(c)example.com (d)")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []emailvalidator.CommentPlacement{
+		emailvalidator.CommentBeforeLocal,
+		emailvalidator.CommentAfterLocal,
+		emailvalidator.CommentBeforeDomain,
+		emailvalidator.CommentAfterDomain,
+	}
+	if len(res.Comments) != len(expected) {
+		t.Fatalf("expected %d comments, saw %+v", len(expected), res.Comments)
+	}
+	for i, c := range res.Comments {
+		if c.Placement != expected[i] {
+			t.Errorf("comment %q: expected placement %s, saw %s", c.Text, expected[i], c.Placement)
+		}
+	}
+}
+
+func TestBuildResult_CommentAdjacentDots(t *testing.T) {
+	tests := []struct {
+		input    string
+		valid    [3]bool
+		stripped string
+	}{
+		{"(c).user@example.com", [3]bool{false, false, false}, ""},
+		{"(c) .user@example.com", [3]bool{false, false, false}, ""},
+		{"().--@b", [3]bool{false, false, false}, ""},
+		{"user.(c).name@example.com", [3]bool{false, false, false}, ""},
+
+		// BehaviorVersion1 allows a local to end with an empty atom, which Stripped must then quote
+		{"user.(c)@example.com", [3]bool{true, false, false}, `"user."@example.com`},
+		{"user. (c)@example.com", [3]bool{true, false, false}, `"user."@example.com`},
+		{"user.@example.com", [3]bool{true, false, false}, `"user."@example.com`},
+
+		{"(c)user.name(c)@example.com", [3]bool{true, true, true}, "user.name@example.com"},
+	}
+	for _, tt := range tests {
+		for v := emailvalidator.BehaviorVersion1; v <= emailvalidator.BehaviorVersion3; v++ {
+			res, err := emailvalidator.BuildResult(tt.input, emailvalidator.WithBehaviorVersion(v))
+			if valid := tt.valid[v-1]; valid != (err == nil) {
+				t.Errorf("%q: v%d: expected valid=%t, saw %v", tt.input, v, valid, err)
+			} else if valid && res.Stripped != tt.stripped {
+				t.Errorf("%q: v%d: expected stripped %q, saw %q", tt.input, v, tt.stripped, res.Stripped)
+			}
+		}
+	}
+}
+
+func TestBuildResult_CommentSeparatedAtoms(t *testing.T) {
+	tests := []struct {
+		input    string
+		valid    bool
+		stripped string
+	}{
+		{"user (c) name@example.com", false, ""},
+		{"user(c)name@example.com", false, ""},
+		{"user (c)name@example.com", false, ""},
+		{"\"user\" (c) \"name\"@example.com", false, ""},
+		{"user (c).name@example.com", true, "user.name@example.com"},
+		{"user.(c) name@example.com", true, "user.name@example.com"},
+		{"(a) user (b)@example.com", true, "user@example.com"},
+	}
+	for _, tt := range tests {
+		res, err := emailvalidator.BuildResult(tt.input)
+		if tt.valid != (err == nil) {
+			t.Errorf("%q: expected valid=%t, saw %v", tt.input, tt.valid, err)
+		} else if tt.valid && res.Stripped != tt.stripped {
+			t.Errorf("%q: expected %q, saw %q", tt.input, tt.stripped, res.Stripped)
+		} else if !tt.valid && !errors.Is(err, emailvalidator.ErrInvalidUnquotedSequence) {
+			t.Errorf("%q: expected ErrInvalidUnquotedSequence, saw %v", tt.input, err)
+		}
+	}
+}
+
+func TestBuildResult_LongWhitespaceRun(t *testing.T) {
+	// each run of whitespace must be scanned once, or this takes tens of seconds
+	for _, input := range []string{
+		"a" + strings.Repeat(" ", 1<<18) + "b@example.com",
+		"a(c)" + strings.Repeat("\t", 1<<18) + "@example.com",
+	} {
+		start := time.Now()
+		_, _ = emailvalidator.BuildResult(input)
+		if elapsed := time.Since(start); elapsed > 5*time.Second {
+			t.Errorf("parsing %d bytes of whitespace took %s", len(input), elapsed)
+		}
+	}
+}
+
+func TestBuildResult_HeaderInjection(t *testing.T) {
+	for _, input := range []string{
+		"user@example.com\r\nBcc: victim@example.org",
+		"user@example.com\n",
+		"us\rer@example.com",
+		"\"us\ner\"@example.com",
+		"user@example.com (a\nb)",
+	} {
+		_, err := emailvalidator.BuildResult(input)
+		if !errors.Is(err, emailvalidator.ErrPossibleHeaderInjection) {
+			t.Errorf("%q: expected ErrPossibleHeaderInjection, saw %v", input, err)
+		}
+		if !errors.Is(err, emailvalidator.ErrUnexpectedNonGraphicCharacter) {
+			t.Errorf("%q: expected ErrUnexpectedNonGraphicCharacter, saw %v", input, err)
+		}
+		if code := emailvalidator.CodeOf(err); code != emailvalidator.CodePossibleHeaderInjection {
+			t.Errorf("%q: expected code %s, saw %s", input, emailvalidator.CodePossibleHeaderInjection, code)
+		}
+	}
+
+	if _, err := emailvalidator.BuildResult("us\ver@example.com"); errors.Is(err, emailvalidator.ErrPossibleHeaderInjection) {
+		t.Errorf("vertical tab should not be reported as header injection: %v", err)
+	}
+}
+
+func TestBuildResult_LocalCharacterSets(t *testing.T) {
+	chars := func(extra, disallowed string) emailvalidator.OptFunc {
+		return func(opt *emailvalidator.ParseOptions) {
+			opt.ExtraAllowedLocalChars = extra
+			opt.DisallowedLocalChars = disallowed
+		}
+	}
+
+	steps := []struct {
+		label string
+		input string
+		opt   emailvalidator.OptFunc
+		err   error
+	}{
+		{label: "default", input: "o'brien@example.com", opt: chars("", "")},
+		{label: "disallowed", input: "o'brien@example.com", opt: chars("", "'`"), err: emailvalidator.ErrDisallowedLocalCharacter},
+		{label: "disallowed-quoted", input: "\"o`brien\"@example.com", opt: chars("", "'`"), err: emailvalidator.ErrDisallowedLocalCharacter},
+		{label: "disallowed-domain", input: "obrien@ex-ample.com", opt: chars("", "-")},
+		{label: "disallowed-comment", input: "obrien(it's me)@example.com", opt: chars("", "'")},
+		{label: "extra", input: "last,first@example.com", opt: chars(",", "")},
+		{label: "extra-quoted", input: "\"last,first\"@example.com", opt: chars(",", "")},
+		{label: "extra-missing", input: "last,first@example.com", opt: chars(";", ""), err: emailvalidator.ErrInvalidUnquotedSequence},
+		{label: "extra-structural", input: "last first@example.com", opt: chars(" ", ""), err: emailvalidator.ErrInvalidUnquotedSequence},
+		{label: "extra-domain", input: "first@exa,mple.com", opt: chars(",", ""), err: emailvalidator.ErrUnexpectedCharacter},
+	}
+
+	for _, step := range steps {
+		t.Run(step.label, func(t *testing.T) {
+			_, err := emailvalidator.BuildResult(step.input, step.opt)
+			if step.err == nil && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			} else if step.err != nil && !errors.Is(err, step.err) {
+				t.Errorf("expected %v, saw %v", step.err, err)
+			}
+		})
+	}
+}
+
+func TestBuildResult_UppercaseLocal(t *testing.T) {
+	steps := []struct {
+		label    string
+		input    string
+		opt      emailvalidator.OptFunc
+		stripped string
+		warnings int
+	}{
+		{label: "default", input: "Jane.Doe@Example.com", opt: func(*emailvalidator.ParseOptions) {}, stripped: "Jane.Doe@Example.com"},
+		{
+			label:    "warn",
+			input:    "Jane.Doe@Example.com",
+			opt:      func(opt *emailvalidator.ParseOptions) { opt.WarnUppercaseLocal = true },
+			stripped: "Jane.Doe@Example.com",
+			warnings: 1,
+		},
+		{
+			label:    "warn-lowercase",
+			input:    "jane.doe@Example.com",
+			opt:      func(opt *emailvalidator.ParseOptions) { opt.WarnUppercaseLocal = true },
+			stripped: "jane.doe@Example.com",
+		},
+		{
+			label:    "lowercase",
+			input:    `"Jane Doe"@Example.com`,
+			opt:      func(opt *emailvalidator.ParseOptions) { opt.LowercaseLocal = true },
+			stripped: `"jane doe"@Example.com`,
+		},
+	}
+
+	for _, step := range steps {
+		t.Run(step.label, func(t *testing.T) {
+			res, err := emailvalidator.BuildResult(step.input, step.opt)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if res.Stripped != step.stripped {
+				t.Errorf("expected stripped %q, saw %q", step.stripped, res.Stripped)
+			}
+			if len(res.Warnings) != step.warnings {
+				t.Fatalf("expected %d warnings, saw %v", step.warnings, res.Warnings)
+			}
+			if step.warnings > 0 && !errors.Is(res.Warnings[0], emailvalidator.ErrUppercaseLocal) {
+				t.Errorf("expected ErrUppercaseLocal, saw %v", res.Warnings[0])
+			}
+		})
+	}
+}
+
+func TestBuildResult_EmptyInput(t *testing.T) {
+	for _, input := range []string{"", " ", "\t \t", " "} {
+		res, err := emailvalidator.BuildResult(input)
+		if !errors.Is(err, emailvalidator.ErrEmptyInput) {
+			t.Errorf("%q: expected ErrEmptyInput, saw %v", input, err)
+		}
+		if perrs := emailvalidator.ErrorsOf(err); len(perrs) != 1 || perrs[0].Message == "" {
+			t.Errorf("%q: expected a single localized error, saw %v", input, perrs)
+		}
+		if res.Input != input {
+			t.Errorf("%q: expected input to be set, saw %q", input, res.Input)
+		}
+	}
+}
+
+func BenchmarkBuildResult(b *testing.B) {
+	benches := []struct {
+		label string
+		input string
+	}{
+		{label: "simple", input: "simple@example.com"},
+		{label: "sub-address", input: "first.last+newsletter@mail.example.co.uk"},
+		{label: "quoted", input: `"john..doe\"smith"@example.com`},
+		{label: "comments", input: "(comment)john.doe(nested (comment))@example.com (trailing)"},
+		{label: "literal", input: "user@[IPv6:2001:db8::1]"},
+		{label: "invalid", input: "john doe@@example..com"},
+		{label: "long", input: strings.Repeat("a", 4096) + "@" + strings.Repeat("b", 4096) + ".com"},
+	}
+
+	for _, bench := range benches {
+		b.Run(bench.label, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_, _ = emailvalidator.BuildResult(bench.input, emailvalidator.WithBehaviorVersion(emailvalidator.LatestBehaviorVersion))
+			}
+		})
+	}
+}
+
+func TestBuildResult_BidiControl(t *testing.T) {
+	for _, input := range []string{
+		"user\u202emoc.elpmaxe@example.com",
+		"user@exa\u200fmple.com",
+		"\"us\u2066er\"@example.com",
+	} {
+		res, err := emailvalidator.BuildResult(input)
+		if !errors.Is(err, emailvalidator.ErrBidiControlCharacter) || !errors.Is(err, emailvalidator.ErrUnexpectedCharacter) {
+			t.Errorf("%q: expected ErrBidiControlCharacter, saw %v", input, err)
+		}
+		if n := len(emailvalidator.ErrorsOf(err)); n != 1 {
+			t.Errorf("%q: expected a single error, saw %d", input, n)
+		}
+		if code := emailvalidator.CodeOf(err); code != emailvalidator.CodeBidiControlCharacter {
+			t.Errorf("%q: expected code %s, saw %s", input, emailvalidator.CodeBidiControlCharacter, code)
+		}
+		if reason := emailvalidator.ReasonOf(res.Err); reason != emailvalidator.ReasonUnsafe {
+			t.Errorf("%q: expected reason %s, saw %s", input, emailvalidator.ReasonUnsafe, reason)
+		}
+	}
+
+	_, err := emailvalidator.BuildResult("us\u00e9r@example.com")
+	if errors.Is(err, emailvalidator.ErrBidiControlCharacter) {
+		t.Errorf("non-ascii letter should not be reported as a bidi control: %v", err)
+	}
+}
+
+func TestBuildResult_Invisible(t *testing.T) {
+	for _, input := range []string{
+		"us\u200ber@example.com",
+		"user@example.com\ufeff",
+		"user\u200d@exam\u00adple.com",
+	} {
+		_, err := emailvalidator.BuildResult(input)
+		if !errors.Is(err, emailvalidator.ErrInvisibleCharacter) || !errors.Is(err, emailvalidator.ErrUnexpectedCharacter) {
+			t.Errorf("%q: expected ErrInvisibleCharacter, saw %v", input, err)
+		}
+		if code := emailvalidator.CodeOf(err); code != emailvalidator.CodeInvisibleCharacter {
+			t.Errorf("%q: expected code %s, saw %s", input, emailvalidator.CodeInvisibleCharacter, code)
+		}
+
+		res, err := emailvalidator.BuildResult(input, emailvalidator.StripInvisible)
+		if err != nil {
+			t.Errorf("%q: unexpected error with StripInvisible: %v", input, err)
+			continue
+		}
+		if res.Input != input || res.Stripped != "user@example.com" {
+			t.Errorf("%q: unexpected result %+v", input, res)
+		}
+		if len(res.Warnings) != 1 || res.Warnings[0].Code != emailvalidator.CodeInvisibleCharacter {
+			t.Errorf("%q: expected ErrInvisibleCharacter warning, saw %v", input, res.Warnings)
+		}
+	}
+
+	_, err := emailvalidator.BuildResult("\u200b", emailvalidator.StripInvisible)
+	if !errors.Is(err, emailvalidator.ErrEmptyInput) {
+		t.Errorf("expected ErrEmptyInput once invisible characters are stripped, saw %v", err)
+	}
+}
+
+func TestBuildResult_PercentEncoded(t *testing.T) {
+	tests := []struct {
+		input    string
+		opts     []emailvalidator.OptFunc
+		valid    bool
+		stripped string
+		encoded  bool
+		decoded  bool
+	}{
+		{input: "user%40example.com", valid: false, encoded: true},
+		{input: "user%40example.com", opts: []emailvalidator.OptFunc{emailvalidator.DecodePercent}, valid: true, stripped: "user@example.com", encoded: true, decoded: true},
+		{input: "user%2Btag%40Example.com", opts: []emailvalidator.OptFunc{emailvalidator.DecodePercent}, valid: true, stripped: "user+tag@Example.com", encoded: true, decoded: true},
+		{input: "100%@example.com", opts: []emailvalidator.OptFunc{emailvalidator.DecodePercent}, valid: true, stripped: "100%@example.com"},
+		{input: "user%2B@example.com", valid: true, stripped: "user%2B@example.com", encoded: true},
+		{input: "user%zz%40example.com", opts: []emailvalidator.OptFunc{emailvalidator.DecodePercent}, valid: false, encoded: true},
+	}
+	for _, tt := range tests {
+		res, err := emailvalidator.BuildResult(tt.input, tt.opts...)
+		if (err == nil) != tt.valid {
+			t.Errorf("%q: expected valid=%t, saw %v", tt.input, tt.valid, err)
+		}
+		if tt.valid && res.Stripped != tt.stripped {
+			t.Errorf("%q: expected %q, saw %q", tt.input, tt.stripped, res.Stripped)
+		}
+		if res.PercentEncoded != tt.encoded || (tt.valid && res.Flags.Has(emailvalidator.FlagPercentEncoded) != tt.encoded) {
+			t.Errorf("%q: expected PercentEncoded=%t, saw %+v", tt.input, tt.encoded, res)
+		}
+		decoded := len(res.Warnings) == 1 && res.Warnings[0].Code == emailvalidator.CodePercentDecoded
+		if decoded != tt.decoded {
+			t.Errorf("%q: expected decoded=%t, saw warnings %v", tt.input, tt.decoded, res.Warnings)
+		}
+	}
+
+	// decoding happens before screening, so encoded bidi controls are still caught
+	_, err := emailvalidator.BuildResult("user%E2%80%AE@example.com", emailvalidator.DecodePercent)
+	if !errors.Is(err, emailvalidator.ErrBidiControlCharacter) {
+		t.Errorf("expected ErrBidiControlCharacter, saw %v", err)
+	}
+}
+
+func TestBuildResult_HTMLEncoded(t *testing.T) {
+	tests := []struct {
+		input    string
+		opts     []emailvalidator.OptFunc
+		valid    bool
+		stripped string
+		encoded  bool
+		decoded  bool
+	}{
+		{input: "user&#64;example.com", valid: false, encoded: true},
+		{input: "user&#64;example.com", opts: []emailvalidator.OptFunc{emailvalidator.DecodeHTMLEntities}, valid: true, stripped: "user@example.com", encoded: true, decoded: true},
+		{input: "user&#x40;example.com", opts: []emailvalidator.OptFunc{emailvalidator.DecodeHTMLEntities}, valid: true, stripped: "user@example.com", encoded: true, decoded: true},
+		{input: "tom&amp;jerry@example.com", valid: false, encoded: true},
+		{input: "tom&amp;jerry@example.com", opts: []emailvalidator.OptFunc{emailvalidator.DecodeHTMLEntities}, valid: true, stripped: "tom&jerry@example.com", encoded: true, decoded: true},
+		{input: "tom&jerry@example.com", opts: []emailvalidator.OptFunc{emailvalidator.DecodeHTMLEntities}, valid: true, stripped: "tom&jerry@example.com"},
+		{input: "a&bogus;@example.com", opts: []emailvalidator.OptFunc{emailvalidator.DecodeHTMLEntities}, valid: false},
+	}
+	for _, tt := range tests {
+		res, err := emailvalidator.BuildResult(tt.input, tt.opts...)
+		if (err == nil) != tt.valid {
+			t.Errorf("%q: expected valid=%t, saw %v", tt.input, tt.valid, err)
+		}
+		if tt.valid && res.Stripped != tt.stripped {
+			t.Errorf("%q: expected %q, saw %q", tt.input, tt.stripped, res.Stripped)
+		}
+		if res.HTMLEncoded != tt.encoded || (tt.valid && res.Flags.Has(emailvalidator.FlagHTMLEncoded) != tt.encoded) {
+			t.Errorf("%q: expected HTMLEncoded=%t, saw %+v", tt.input, tt.encoded, res)
+		}
+		decoded := len(res.Warnings) == 1 && res.Warnings[0].Code == emailvalidator.CodeHTMLDecoded
+		if decoded != tt.decoded {
+			t.Errorf("%q: expected decoded=%t, saw warnings %v", tt.input, tt.decoded, res.Warnings)
+		}
+	}
+
+	res, err := emailvalidator.BuildResult("user%26%2364%3Bexample.com", emailvalidator.DecodePercent, emailvalidator.DecodeHTMLEntities)
+	if err != nil || res.Stripped != "user@example.com" || len(res.Warnings) != 2 {
+		t.Errorf("expected percent then html decoding, saw %+v (%v)", res, err)
+	}
+}
+
+func TestBuildResult_RepairPunctuation(t *testing.T) {
+	tests := []struct {
+		input    string
+		stripped string
+		fixes    []emailvalidator.Fix
+	}{
+		{
+			input:    "user\uff20example\u3002com",
+			stripped: "user@example.com",
+			fixes: []emailvalidator.Fix{
+				{Kind: emailvalidator.FixPunctuation, Position: 4, From: "\uff20", To: "@", Confidence: 0.95},
+				{Kind: emailvalidator.FixPunctuation, Position: 14, From: "\u3002", To: ".", Confidence: 0.95},
+			},
+		},
+		{
+			input:    "\u201cjohn doe\u201d@example.com",
+			stripped: "\"john doe\"@example.com",
+			fixes: []emailvalidator.Fix{
+				{Kind: emailvalidator.FixPunctuation, Position: 0, From: "\u201c", To: "\"", Confidence: 0.95},
+				{Kind: emailvalidator.FixPunctuation, Position: 11, From: "\u201d", To: "\"", Confidence: 0.95},
+			},
+		},
+		{
+			input:    "o\u2019brien@example.com",
+			stripped: "o'brien@example.com",
+			fixes:    []emailvalidator.Fix{{Kind: emailvalidator.FixPunctuation, Position: 1, From: "\u2019", To: "'", Confidence: 0.95}},
+		},
+		{input: "user@example.com", stripped: "user@example.com"},
+	}
+	for _, tt := range tests {
+		if _, err := emailvalidator.BuildResult(tt.input); tt.fixes != nil && err == nil {
+			t.Errorf("%q: expected error without RepairPunctuation", tt.input)
+		}
+
+		res, err := emailvalidator.BuildResult(tt.input, emailvalidator.RepairPunctuation)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tt.input, err)
+			continue
+		}
+		if res.Input != tt.input || res.Stripped != tt.stripped {
+			t.Errorf("%q: expected %q, saw %q", tt.input, tt.stripped, res.Stripped)
+		}
+		if !reflect.DeepEqual(res.Fixes, tt.fixes) {
+			t.Errorf("%q: expected fixes %+v, saw %+v", tt.input, tt.fixes, res.Fixes)
+		}
+	}
+}
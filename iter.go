@@ -0,0 +1,27 @@
+//go:build go1.23
+
+package emailvalidator
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"iter"
+)
+
+// Results returns an iterator over the validation results of each newline-delimited address read from r.  Each
+// iteration yields the Result and error returned by BuildResult.  If reading from r fails, a final iteration yields a
+// zero Result and the read error.
+func Results(r io.Reader, opts ...OptFunc) iter.Seq2[Result, error] {
+	return func(yield func(Result, error) bool) {
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			if !yield(BuildResult(scanner.Text(), opts...)) {
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			yield(Result{}, fmt.Errorf("error reading addresses: %w", err))
+		}
+	}
+}
@@ -0,0 +1,44 @@
+//go:build go1.23
+
+package emailvalidator_test
+
+import (
+	"strings"
+	"testing"
+
+	emailvalidator "github.com/dcarbone/go-email-validator"
+)
+
+func TestResults(t *testing.T) {
+	var (
+		valid, invalid int
+
+		input = strings.NewReader("simple@example.com\nabc.example.com\nx@example.com\n")
+	)
+
+	for res, err := range emailvalidator.Results(input) {
+		if err != nil {
+			invalid++
+			continue
+		}
+		if res.Domain != "example.com" {
+			t.Errorf("unexpected domain %q", res.Domain)
+		}
+		valid++
+	}
+
+	if valid != 2 || invalid != 1 {
+		t.Errorf("expected 2 valid and 1 invalid, saw %d and %d", valid, invalid)
+	}
+}
+
+func TestResults_Break(t *testing.T) {
+	seen := 0
+	for range emailvalidator.Results(strings.NewReader("a@example.com\nb@example.com\n")) {
+		seen++
+		break
+	}
+	if seen != 1 {
+		t.Errorf("expected 1 iteration, saw %d", seen)
+	}
+}
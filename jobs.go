@@ -0,0 +1,239 @@
+package emailvalidator
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// JobHandler defaults, used unless otherwise configured
+const (
+	// DefaultJobRetention is how long a finished job's results are kept
+	DefaultJobRetention = time.Hour
+
+	// DefaultJobMaxEmails is the largest number of addresses accepted within a single job
+	DefaultJobMaxEmails = 100000
+
+	// DefaultJobMaxRunning is the largest number of jobs that may run at once
+	DefaultJobMaxRunning = 4
+)
+
+// MaxJobBodyBytes is the largest request body JobHandler will read when creating a job
+const MaxJobBodyBytes = 16 << 20
+
+// JobStatus is the lifecycle state of an asynchronous validation job
+type JobStatus string
+
+const (
+	JobRunning  JobStatus = "running"
+	JobComplete JobStatus = "complete"
+)
+
+// Job is the body written by JobHandler describing a single asynchronous validation job
+type Job struct {
+	ID     string    `json:"id"`
+	Status JobStatus `json:"status"`
+
+	// Total is the number of addresses submitted, and Done the number validated so far
+	Total int `json:"total"`
+	Done  int `json:"done"`
+
+	// Reports contains one Report per submitted address, in submission order.  It is only populated once the job is
+	// complete.
+	Reports []Report `json:"reports,omitempty"`
+
+	finished time.Time
+}
+
+// JobHandler validates large batches of addresses in the background, for lists that would time out if validated
+// within a single request.  It serves two routes relative to where it is mounted, e.g. via
+// http.StripPrefix("/jobs", jobHandler):
+//
+//   - POST / accepts newline-delimited addresses, or a JSON array of addresses if the Content-Type is
+//     "application/json", and responds 202 with the new Job and a Location header
+//   - GET /{id} responds with the Job's progress, including its Reports once complete
+//
+// Jobs with a body larger than MaxJobBodyBytes or more than MaxEmails addresses are refused with a 413, and new jobs
+// are refused with a 503 while MaxRunning jobs are running.  Finished jobs are forgotten once Retention has elapsed.
+//
+// Addresses that could not be validated, e.g. because a remote validator failed, are reported with VerdictUnknown.
+type JobHandler struct {
+	// Retention is how long a finished job's results are kept.  Defaults to DefaultJobRetention.
+	Retention time.Duration
+
+	// MaxEmails is the largest number of addresses accepted within a single job.  Defaults to DefaultJobMaxEmails.
+	MaxEmails int
+
+	// MaxRunning is the largest number of jobs that may run at once.  Defaults to DefaultJobMaxRunning.
+	MaxRunning int
+
+	validator EmailValidator
+
+	mu      sync.Mutex
+	jobs    map[string]*Job
+	running int
+}
+
+func NewJobHandler(validator EmailValidator) *JobHandler {
+	return &JobHandler{
+		Retention:  DefaultJobRetention,
+		MaxEmails:  DefaultJobMaxEmails,
+		MaxRunning: DefaultJobMaxRunning,
+		validator:  validator,
+		jobs:       make(map[string]*Job),
+	}
+}
+
+func (h *JobHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.expire(time.Now())
+
+	id := strings.Trim(r.URL.Path, "/")
+	switch {
+	case id == "" && r.Method == http.MethodPost:
+		h.create(w, r)
+	case id != "" && r.Method == http.MethodGet:
+		h.get(w, id)
+	case id == "":
+		w.Header().Set("Allow", "POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	default:
+		w.Header().Set("Allow", "GET")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *JobHandler) create(w http.ResponseWriter, r *http.Request) {
+	var (
+		emails   []string
+		err      error
+		tooLarge *http.MaxBytesError
+	)
+	r.Body = http.MaxBytesReader(w, r.Body, MaxJobBodyBytes)
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		if err = json.NewDecoder(r.Body).Decode(&emails); err != nil && !errors.As(err, &tooLarge) {
+			http.Error(w, "body must be a json array of addresses", http.StatusBadRequest)
+			return
+		}
+	} else {
+		scanner := bufio.NewScanner(r.Body)
+		for scanner.Scan() && len(emails) <= h.MaxEmails {
+			emails = append(emails, scanner.Text())
+		}
+		if err = scanner.Err(); err != nil && !errors.As(err, &tooLarge) {
+			http.Error(w, "error reading body", http.StatusBadRequest)
+			return
+		}
+	}
+	if tooLarge != nil {
+		http.Error(w, "body too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	if len(emails) == 0 {
+		http.Error(w, "no addresses provided", http.StatusBadRequest)
+		return
+	}
+	if len(emails) > h.MaxEmails {
+		http.Error(w, fmt.Sprintf("jobs may contain at most %d addresses", h.MaxEmails), http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	id, err := newJobID()
+	if err != nil {
+		http.Error(w, "error creating job", http.StatusInternalServerError)
+		return
+	}
+
+	job := &Job{ID: id, Status: JobRunning, Total: len(emails)}
+	h.mu.Lock()
+	if h.running >= h.MaxRunning {
+		h.mu.Unlock()
+		w.Header().Set("Retry-After", "60")
+		http.Error(w, "too many jobs running", http.StatusServiceUnavailable)
+		return
+	}
+	h.running++
+	h.jobs[id] = job
+	snapshot := *job
+	h.mu.Unlock()
+
+	go h.run(job, emails)
+
+	// prefer the original request path, as it will include any prefix stripped by the mux
+	base := r.URL.Path
+	if u, err := url.ParseRequestURI(r.RequestURI); err == nil {
+		base = u.Path
+	}
+
+	w.Header().Set("Location", strings.TrimSuffix(base, "/")+"/"+id)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	_ = json.NewEncoder(w).Encode(snapshot)
+}
+
+func (h *JobHandler) get(w http.ResponseWriter, id string) {
+	h.mu.Lock()
+	job, ok := h.jobs[id]
+	var snapshot Job
+	if ok {
+		snapshot = *job
+	}
+	h.mu.Unlock()
+
+	if !ok {
+		http.Error(w, "job not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(snapshot)
+}
+
+// run validates each of emails, recording progress on job.  Jobs are not tied to the request that created them, so
+// they run to completion even if the client disconnects.
+func (h *JobHandler) run(job *Job, emails []string) {
+	reports := make([]Report, len(emails))
+	for i, email := range emails {
+		start := time.Now()
+		res, err := h.validator.Validate(context.Background(), email)
+		reports[i] = NewReport(res, err, time.Since(start))
+
+		h.mu.Lock()
+		job.Done = i + 1
+		h.mu.Unlock()
+	}
+
+	h.mu.Lock()
+	job.Reports = reports
+	job.Status = JobComplete
+	job.finished = time.Now()
+	h.running--
+	h.mu.Unlock()
+}
+
+// expire forgets jobs that finished longer than Retention ago
+func (h *JobHandler) expire(now time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for id, job := range h.jobs {
+		if job.Status == JobComplete && now.Sub(job.finished) > h.Retention {
+			delete(h.jobs, id)
+		}
+	}
+}
+
+func newJobID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,154 @@
+package emailvalidator_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	emailvalidator "github.com/dcarbone/go-email-validator"
+)
+
+func TestJobHandler(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle("/jobs/", http.StripPrefix("/jobs", emailvalidator.NewJobHandler(emailvalidator.NewValidator())))
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	resp, err := srv.Client().Post(srv.URL+"/jobs/", "application/json", strings.NewReader(`["simple@example.com","a@b@example.com"]`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var job emailvalidator.Job
+	err = json.NewDecoder(resp.Body).Decode(&job)
+	_ = resp.Body.Close()
+	if err != nil {
+		t.Fatalf("error decoding job: %v", err)
+	}
+	if resp.StatusCode != http.StatusAccepted || job.ID == "" || job.Total != 2 {
+		t.Fatalf("unexpected response %d: %+v", resp.StatusCode, job)
+	}
+	if loc := resp.Header.Get("Location"); loc != "/jobs/"+job.ID {
+		t.Errorf("unexpected location %q", loc)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for job.Status != emailvalidator.JobComplete {
+		if time.Now().After(deadline) {
+			t.Fatalf("job did not complete: %+v", job)
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		resp, err = srv.Client().Get(srv.URL + "/jobs/" + job.ID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		err = json.NewDecoder(resp.Body).Decode(&job)
+		_ = resp.Body.Close()
+		if err != nil {
+			t.Fatalf("error decoding job: %v", err)
+		}
+	}
+
+	if job.Done != 2 || len(job.Reports) != 2 {
+		t.Fatalf("unexpected job: %+v", job)
+	}
+	if job.Reports[0].Verdict != emailvalidator.VerdictValid || job.Reports[1].Verdict != emailvalidator.VerdictInvalid {
+		t.Errorf("unexpected verdicts: %q, %q", job.Reports[0].Verdict, job.Reports[1].Verdict)
+	}
+
+	resp, err = srv.Client().Get(srv.URL + "/jobs/unknown")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected status 404, saw %d", resp.StatusCode)
+	}
+}
+
+// blockingValidator blocks every validation until release is closed
+type blockingValidator struct {
+	release chan struct{}
+}
+
+func (v blockingValidator) Validate(_ context.Context, email string) (emailvalidator.Result, error) {
+	<-v.release
+	return emailvalidator.Result{Input: email}, nil
+}
+
+func TestJobHandler_Limits(t *testing.T) {
+	validator := blockingValidator{release: make(chan struct{})}
+	defer close(validator.release)
+
+	handler := emailvalidator.NewJobHandler(validator)
+	handler.MaxEmails = 2
+	handler.MaxRunning = 1
+
+	steps := []struct {
+		label string
+		ctype string
+		body  string
+		code  int
+	}{
+		{label: "too-many-lines", ctype: "text/plain", body: "a@example.com\nb@example.com\nc@example.com", code: http.StatusRequestEntityTooLarge},
+		{label: "too-many-json", ctype: "application/json", body: `["a@example.com","b@example.com","c@example.com"]`, code: http.StatusRequestEntityTooLarge},
+		{label: "too-large", ctype: "application/json", body: `["` + strings.Repeat("x", emailvalidator.MaxJobBodyBytes) + `"]`, code: http.StatusRequestEntityTooLarge},
+		{label: "accepted", ctype: "text/plain", body: "a@example.com\nb@example.com", code: http.StatusAccepted},
+		{label: "too-many-running", ctype: "text/plain", body: "a@example.com", code: http.StatusServiceUnavailable},
+	}
+	for _, step := range steps {
+		t.Run(step.label, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(step.body))
+			req.Header.Set("Content-Type", step.ctype)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != step.code {
+				t.Errorf("expected status %d, saw %d: %s", step.code, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestJobHandler_RemoteFailure(t *testing.T) {
+	remote := httptest.NewServer(http.NotFoundHandler())
+	remote.Close()
+
+	handler := emailvalidator.NewJobHandler(emailvalidator.NewClient(remote.URL, nil))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader("simple@example.com")))
+	var job emailvalidator.Job
+	if err := json.NewDecoder(rec.Body).Decode(&job); err != nil {
+		t.Fatalf("error decoding job: %v", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for job.Status != emailvalidator.JobComplete {
+		if time.Now().After(deadline) {
+			t.Fatalf("job did not complete: %+v", job)
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		rec = httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/"+job.ID, nil))
+		if err := json.NewDecoder(rec.Body).Decode(&job); err != nil {
+			t.Fatalf("error decoding job: %v", err)
+		}
+	}
+	if len(job.Reports) != 1 || job.Reports[0].Verdict != emailvalidator.VerdictUnknown {
+		t.Errorf("expected an unknown verdict, saw %+v", job.Reports)
+	}
+
+	// the job finished before this request, so has outlived any shorter retention
+	handler.Retention = time.Nanosecond
+	time.Sleep(time.Millisecond)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/"+job.ID, nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected expired job to be forgotten, saw status %d", rec.Code)
+	}
+}
@@ -0,0 +1,110 @@
+package emailvalidator
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Journal records which records of a bulk run have been completed, allowing an interrupted run to be resumed by
+// re-running it over the same input with the same Journal.  Completed records are skipped entirely: they are not
+// re-validated, re-written, re-delivered to any Sink, or counted in the Summary.
+//
+// A record is marked complete only after its output has been written and any Sink flushed, so a crash may cause the
+// final record of a run to be processed twice, but never skipped.  As a consequence, Sinks that buffer results, e.g.
+// WebhookSink, deliver each result individually when a Journal is configured.
+type Journal interface {
+	// Completed returns true if key was marked complete by a previous run
+	Completed(key string) bool
+
+	// Complete marks key as complete
+	Complete(key string) error
+}
+
+// WithJournal sets the Journal used to checkpoint and resume a bulk run
+func WithJournal(journal Journal) BulkOptFunc {
+	return func(opt *BulkOptions) {
+		opt.Journal = journal
+	}
+}
+
+// journalKey identifies a record by its offset within the input and a hash of its content, so that a resumed run over
+// modified input re-validates any record that changed.
+func journalKey(offset int, fields ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(fields, "\x1f")))
+	return strconv.Itoa(offset) + ":" + hex.EncodeToString(sum[:16])
+}
+
+// completeRecord flushes sink, if it buffers results, so the record's delivery is durable, then marks key complete in
+// journal
+func completeRecord(sink Sink, journal Journal, key string) error {
+	if sink != nil {
+		if err := flushSink(sink, nil); err != nil {
+			return err
+		}
+	}
+	return journal.Complete(key)
+}
+
+// FileJournal is a Journal persisted to an append-only file, one key per line.  It is safe for concurrent use.
+type FileJournal struct {
+	mu   sync.Mutex
+	f    *os.File
+	keys map[string]struct{}
+}
+
+// OpenFileJournal opens the journal at path, creating it if it does not exist.  Keys from any previous run are loaded.
+func OpenFileJournal(path string) (*FileJournal, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("error opening journal: %w", err)
+	}
+
+	j := &FileJournal{f: f, keys: make(map[string]struct{})}
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if key := scanner.Text(); key != "" {
+			j.keys[key] = struct{}{}
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("error reading journal: %w", err)
+	}
+
+	return j, nil
+}
+
+func (j *FileJournal) Completed(key string) bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	_, ok := j.keys[key]
+	return ok
+}
+
+func (j *FileJournal) Complete(key string) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if _, err := j.f.WriteString(key + "\n"); err != nil {
+		return fmt.Errorf("error writing journal: %w", err)
+	}
+	j.keys[key] = struct{}{}
+	return nil
+}
+
+// Len returns the number of completed keys
+func (j *FileJournal) Len() int {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return len(j.keys)
+}
+
+func (j *FileJournal) Close() error {
+	return j.f.Close()
+}
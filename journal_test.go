@@ -0,0 +1,179 @@
+package emailvalidator_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	emailvalidator "github.com/dcarbone/go-email-validator"
+)
+
+func TestValidateCSV_Resume(t *testing.T) {
+	const input = `name,email
+a,a@example.com
+b,b@example.com
+c,abc.example.com
+d,d@example.com
+`
+	path := filepath.Join(t.TempDir(), "journal")
+
+	var expected bytes.Buffer
+	if _, err := emailvalidator.ValidateCSV(context.Background(), strings.NewReader(input), &expected, 1, emailvalidator.HasHeader); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// first run is interrupted after two records
+	journal, err := emailvalidator.OpenFileJournal(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var out bytes.Buffer
+	summary, err := emailvalidator.ValidateCSV(
+		ctx,
+		strings.NewReader(input),
+		&out,
+		1,
+		emailvalidator.HasHeader,
+		emailvalidator.WithJournal(journal),
+		emailvalidator.WithProgress(func(done, _ int) {
+			if done == 2 {
+				cancel()
+			}
+		}),
+	)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, saw %v", err)
+	}
+	if summary.Total != 2 || journal.Len() != 3 {
+		t.Fatalf("expected 2 records and 3 journal entries, saw %d and %d", summary.Total, journal.Len())
+	}
+	if err = journal.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// second run resumes from the journal on disk
+	journal, err = emailvalidator.OpenFileJournal(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer func() { _ = journal.Close() }()
+
+	summary, err = emailvalidator.ValidateCSV(
+		context.Background(),
+		strings.NewReader(input),
+		&out,
+		1,
+		emailvalidator.HasHeader,
+		emailvalidator.WithJournal(journal),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if summary.Total != 2 || summary.Resumed != 3 {
+		t.Errorf("expected 2 records and 3 resumed, saw %d and %d", summary.Total, summary.Resumed)
+	}
+	if out.String() != expected.String() {
+		t.Errorf("expected output:\n%s\nsaw:\n%s", expected.String(), out.String())
+	}
+}
+
+func TestWriteReports_Resume(t *testing.T) {
+	journal, err := emailvalidator.OpenFileJournal(filepath.Join(t.TempDir(), "journal"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer func() { _ = journal.Close() }()
+
+	if _, err = emailvalidator.WriteReports(context.Background(), strings.NewReader("a@example.com\n"), new(bytes.Buffer), emailvalidator.WithJournal(journal)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the first line is unchanged, the second is new
+	var out bytes.Buffer
+	summary, err := emailvalidator.WriteReports(context.Background(), strings.NewReader("a@example.com\nb@example.com\n"), &out, emailvalidator.WithJournal(journal))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if summary.Total != 1 || summary.Resumed != 1 || !strings.Contains(out.String(), "b@example.com") || strings.Contains(out.String(), "a@example.com") {
+		t.Errorf("unexpected resume: %+v\n%s", summary, out.String())
+	}
+
+	// a changed record at a completed offset is re-validated
+	summary, err = emailvalidator.WriteReports(context.Background(), strings.NewReader("c@example.com\n"), new(bytes.Buffer), emailvalidator.WithJournal(journal))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if summary.Total != 1 || summary.Resumed != 0 {
+		t.Errorf("expected changed record to be re-validated, saw %+v", summary)
+	}
+}
+
+// bufferingSink holds results until flushed
+type bufferingSink struct {
+	pending, delivered int
+}
+
+func (s *bufferingSink) OnResult(emailvalidator.Result) error {
+	s.pending++
+	return nil
+}
+
+func (s *bufferingSink) Flush() error {
+	s.delivered += s.pending
+	s.pending = 0
+	return nil
+}
+
+// checkedJournal calls check before marking each key complete
+type checkedJournal struct {
+	check func() error
+	keys  map[string]bool
+}
+
+func (j *checkedJournal) Completed(key string) bool {
+	return j.keys[key]
+}
+
+func (j *checkedJournal) Complete(key string) error {
+	if err := j.check(); err != nil {
+		return err
+	}
+	j.keys[key] = true
+	return nil
+}
+
+func TestJournal_FlushesSinkBeforeComplete(t *testing.T) {
+	runs := map[string]func(emailvalidator.Sink, emailvalidator.Journal) error{
+		"reports": func(sink emailvalidator.Sink, journal emailvalidator.Journal) error {
+			_, err := emailvalidator.WriteReports(context.Background(), strings.NewReader("a@example.com\nb@example.com\n"), new(bytes.Buffer), emailvalidator.WithSink(sink), emailvalidator.WithJournal(journal))
+			return err
+		},
+		"csv": func(sink emailvalidator.Sink, journal emailvalidator.Journal) error {
+			_, err := emailvalidator.ValidateCSV(context.Background(), strings.NewReader("a@example.com\nb@example.com\n"), new(bytes.Buffer), 0, emailvalidator.WithSink(sink), emailvalidator.WithJournal(journal))
+			return err
+		},
+	}
+	for label, run := range runs {
+		t.Run(label, func(t *testing.T) {
+			sink := new(bufferingSink)
+			journal := &checkedJournal{keys: make(map[string]bool), check: func() error {
+				if sink.pending > 0 {
+					return errors.New("record completed before its result was delivered")
+				}
+				return nil
+			}}
+			if err := run(sink, journal); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if sink.delivered != 2 || len(journal.keys) != 2 {
+				t.Errorf("expected 2 delivered and completed, saw %d and %d", sink.delivered, len(journal.keys))
+			}
+		})
+	}
+}
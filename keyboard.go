@@ -0,0 +1,73 @@
+package emailvalidator
+
+// qwertyRows contains the rows of a QWERTY keyboard, top to bottom.  Each row is offset roughly half a key to the
+// right of the row above it.
+var qwertyRows = []string{
+	"1234567890-",
+	"qwertyuiop",
+	"asdfghjkl",
+	"zxcvbnm",
+}
+
+// qwertyAdjacent contains, for each key, the set of keys physically adjacent to it
+var qwertyAdjacent = buildAdjacency(qwertyRows)
+
+func buildAdjacency(rows []string) map[byte]map[byte]struct{} {
+	adj := make(map[byte]map[byte]struct{})
+	at := func(r, c int) (byte, bool) {
+		if r < 0 || r >= len(rows) || c < 0 || c >= len(rows[r]) {
+			return 0, false
+		}
+		return rows[r][c], true
+	}
+	for r, row := range rows {
+		for c := 0; c < len(row); c++ {
+			key := row[c]
+			adj[key] = make(map[byte]struct{})
+			for _, pos := range [][2]int{{r, c - 1}, {r, c + 1}, {r - 1, c}, {r - 1, c + 1}, {r + 1, c - 1}, {r + 1, c}} {
+				if n, ok := at(pos[0], pos[1]); ok {
+					adj[key][n] = struct{}{}
+				}
+			}
+		}
+	}
+	return adj
+}
+
+// KeyboardAdjacentCost is the substitution cost KeyboardDistance applies to physically adjacent keys
+const KeyboardAdjacentCost = 0.5
+
+// KeyboardDistance is a Levenshtein distance in which substituting a key for one physically adjacent to it on a
+// QWERTY keyboard costs KeyboardAdjacentCost rather than 1, so likely fat-finger typos such as "gmaul.com" rank
+// closer to their intended domain than unrelated edits do.
+func KeyboardDistance(a, b string) float64 {
+	if a == b {
+		return 0
+	}
+
+	prev := make([]float64, len(b)+1)
+	curr := make([]float64, len(b)+1)
+	for j := range prev {
+		prev[j] = float64(j)
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = float64(i)
+		for j := 1; j <= len(b); j++ {
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+substitutionCost(a[i-1], b[j-1]))
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
+
+func substitutionCost(x, y byte) float64 {
+	if x == y {
+		return 0
+	}
+	if _, ok := qwertyAdjacent[x][y]; ok {
+		return KeyboardAdjacentCost
+	}
+	return 1
+}
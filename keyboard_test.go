@@ -0,0 +1,39 @@
+package emailvalidator_test
+
+import (
+	"testing"
+
+	emailvalidator "github.com/dcarbone/go-email-validator"
+)
+
+func TestKeyboardDistance(t *testing.T) {
+	steps := []struct {
+		a, b     string
+		expected float64
+	}{
+		{"gmail.com", "gmail.com", 0},
+		{"gmaul.com", "gmail.com", 0.5},
+		{"hitmail.com", "hotmail.com", 0.5},
+		{"gmapl.com", "gmail.com", 1},
+		{"gmai.com", "gmail.com", 1},
+	}
+	for _, step := range steps {
+		if d := emailvalidator.KeyboardDistance(step.a, step.b); d != step.expected {
+			t.Errorf("KeyboardDistance(%q, %q): expected %v, saw %v", step.a, step.b, step.expected, d)
+		}
+	}
+}
+
+func TestSuggester_KeyboardRanking(t *testing.T) {
+	// "hitmail.com" is a single substitution away from both candidates, but only "hotmail.com" is an adjacent-key
+	// substitution, so it should rank first.
+	s := emailvalidator.NewSuggester(emailvalidator.WithDomains("hitmall.com", "hotmail.com"))
+
+	out := s.SuggestDomain("hitmail.com")
+	if len(out) != 2 {
+		t.Fatalf("expected 2 suggestions, saw %v", out)
+	}
+	if out[0].Domain != "hotmail.com" {
+		t.Errorf("expected hotmail.com to rank first, saw %v", out)
+	}
+}
@@ -0,0 +1,108 @@
+package emailvalidator
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+// registeredLiteralTags contains the lower-cased tags registered in IANA's "Address Literal Tags" registry
+var registeredLiteralTags = map[string]struct{}{
+	"ipv6": {},
+}
+
+// checkLiteral populates the LiteralTag and LiteralContent fields of res, returning any errors seen while validating
+// the literal.
+func checkLiteral(res *Result, opts *ParseOptions) []error {
+	var errs []error
+
+	if !strings.HasSuffix(res.Domain, "]") {
+		return append(errs, newParseError(fmt.Errorf("%w: missing closing bracket", ErrInvalidAddressLiteral), -1, "", SegmentDomain))
+	}
+
+	content := res.Domain[1 : len(res.Domain)-1]
+
+	// RFC 5321 section 4.1.3: General-address-literal = Standardized-tag ":" 1*dcontent
+	if idx := strings.IndexByte(content, ':'); idx >= 0 {
+		res.LiteralTag = content[:idx]
+		res.LiteralContent = content[idx+1:]
+
+		if !isLdhStr(res.LiteralTag) {
+			errs = append(errs, newParseError(fmt.Errorf("%w: tag %q is not a valid Ldh-str", ErrInvalidAddressLiteral, res.LiteralTag), -1, "", SegmentDomain))
+		} else if _, ok := registeredLiteralTags[strings.ToLower(res.LiteralTag)]; !ok && opts.StrictLiteralTags {
+			errs = append(errs, newParseError(fmt.Errorf("%w: %q", ErrUnregisteredLiteralTag, res.LiteralTag), -1, "", SegmentDomain))
+		}
+		if res.LiteralContent == "" {
+			errs = append(errs, newParseError(fmt.Errorf("%w: empty content", ErrInvalidAddressLiteral), -1, "", SegmentDomain))
+		} else if strings.EqualFold(res.LiteralTag, "IPv6") {
+			if addr, err := parseIPv6Literal(res.LiteralContent); err != nil {
+				errs = append(errs, newParseError(err, -1, "", SegmentDomain))
+			} else {
+				res.LiteralAddr = addr
+			}
+		}
+		return errs
+	}
+
+	// untagged literals must be IPv4
+	res.LiteralContent = content
+	if addr, err := netip.ParseAddr(content); err != nil || !addr.Is4() {
+		errs = append(errs, newParseError(fmt.Errorf("%w: %q is not an IPv4 address", ErrInvalidAddressLiteral, content), -1, "", SegmentDomain))
+	} else {
+		res.LiteralAddr = addr
+	}
+
+	return errs
+}
+
+// parseIPv6Literal validates content per the RFC 5321 IPv6-addr production: full, "::"-compressed (at most once),
+// and IPv4-suffixed forms are accepted; zone identifiers and bare IPv4 addresses are not.
+func parseIPv6Literal(content string) (netip.Addr, error) {
+	if strings.IndexByte(content, '%') >= 0 {
+		return netip.Addr{}, fmt.Errorf("%w: zone identifiers are not allowed in %q", ErrInvalidIPv6Literal, content)
+	}
+	if strings.Count(content, "::") > 1 {
+		return netip.Addr{}, fmt.Errorf("%w: \"::\" may appear only once in %q", ErrInvalidIPv6Literal, content)
+	}
+	addr, err := netip.ParseAddr(content)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("%w: %v", ErrInvalidIPv6Literal, err)
+	}
+	if !addr.Is6() {
+		return netip.Addr{}, fmt.Errorf("%w: %q is not an IPv6 address", ErrInvalidIPv6Literal, content)
+	}
+	return addr, nil
+}
+
+// isLdhStr returns true if s is a valid RFC 5321 Ldh-str: letters, digits, and hyphens, not ending with a hyphen
+func isLdhStr(s string) bool {
+	if s == "" || s[len(s)-1] == '-' {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9', c == '-':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// checkDtext returns an error if dec is not an RFC 5321 dtext character, i.e. printable US-ASCII excluding "[", "]",
+// and "\".
+func checkDtext(dec uint8, i int) error {
+	switch {
+	case dec == 32 || dec == 9:
+		return fmt.Errorf("%w: whitespace at position %d", ErrInvalidLiteralCharacter, i)
+	case dec < 32 || dec == 127:
+		return fmt.Errorf("%w: control character %d at position %d", ErrInvalidLiteralCharacter, dec, i)
+	case dec > 127:
+		return fmt.Errorf("%w: non-ascii byte at position %d", ErrInvalidLiteralCharacter, i)
+	case dec == 91 || dec == 93:
+		return fmt.Errorf("%w: bracket at position %d", ErrInvalidLiteralCharacter, i)
+	case dec == 92:
+		return fmt.Errorf("%w: backslash at position %d", ErrInvalidLiteralCharacter, i)
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package emailvalidator_test
+
+import (
+	"errors"
+	"testing"
+
+	emailvalidator "github.com/dcarbone/go-email-validator"
+)
+
+func TestBuildResult_Literals(t *testing.T) {
+	steps := []struct {
+		label   string
+		input   string
+		opts    []emailvalidator.OptFunc
+		tag     string
+		content string
+		err     error
+	}{
+		{
+			label:   "ipv4",
+			input:   "user@[192.0.2.1]",
+			content: "192.0.2.1",
+		},
+		{
+			label:   "ipv6",
+			input:   "user@[IPv6:2001:db8::1]",
+			tag:     "IPv6",
+			content: "2001:db8::1",
+		},
+		{
+			label:   "general",
+			input:   "user@[x-custom:some.content]",
+			tag:     "x-custom",
+			content: "some.content",
+		},
+		{
+			label: "general-strict",
+			input: "user@[x-custom:some.content]",
+			opts: []emailvalidator.OptFunc{func(opt *emailvalidator.ParseOptions) {
+				opt.StrictLiteralTags = true
+			}},
+			err: emailvalidator.ErrUnregisteredLiteralTag,
+		},
+		{
+			label: "ipv6-strict",
+			input: "user@[ipv6:2001:db8::1]",
+			opts: []emailvalidator.OptFunc{func(opt *emailvalidator.ParseOptions) {
+				opt.StrictLiteralTags = true
+			}},
+			tag:     "ipv6",
+			content: "2001:db8::1",
+		},
+		{
+			label: "not-ipv4",
+			input: "user@[256.0.0.1]",
+			err:   emailvalidator.ErrInvalidAddressLiteral,
+		},
+		{
+			label: "empty-content",
+			input: "user@[x-custom:]",
+			err:   emailvalidator.ErrInvalidAddressLiteral,
+		},
+		{
+			label: "unclosed",
+			input: "user@[192.0.2.1",
+			err:   emailvalidator.ErrInvalidAddressLiteral,
+		},
+	}
+
+	for _, step := range steps {
+		t.Run(step.label, func(t *testing.T) {
+			res, err := emailvalidator.BuildResult(step.input, step.opts...)
+			if step.err != nil {
+				if !errors.Is(err, step.err) {
+					t.Errorf("expected %v, saw %v", step.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if res.LiteralTag != step.tag || res.LiteralContent != step.content {
+				t.Errorf("expected %q / %q, saw %q / %q", step.tag, step.content, res.LiteralTag, res.LiteralContent)
+			}
+		})
+	}
+}
+
+func TestBuildResult_DomainHyphens(t *testing.T) {
+	if _, err := emailvalidator.BuildResult("user@my-domain.example.com"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	for _, input := range []string{"user@-domain.com", "user@domain-.com", "user@domain.com-"} {
+		if _, err := emailvalidator.BuildResult(input); !errors.Is(err, emailvalidator.ErrUnexpectedCharacter) {
+			t.Errorf("%s: expected ErrUnexpectedCharacter, saw %v", input, err)
+		}
+	}
+}
+
+func TestBuildResult_IPv6Literals(t *testing.T) {
+	steps := []struct {
+		label  string
+		input  string
+		mapped bool
+		err    error
+	}{
+		{label: "full", input: "user@[IPv6:2001:0db8:85a3:0000:0000:8a2e:0370:7334]"},
+		{label: "compressed", input: "user@[IPv6:2001:db8::8a2e:370:7334]"},
+		{label: "all-zero", input: "user@[IPv6:::]"},
+		{label: "loopback", input: "user@[IPv6:::1]"},
+		{label: "ipv4-mapped", input: "user@[IPv6:::ffff:192.0.2.1]", mapped: true},
+		{label: "ipv4-full", input: "user@[IPv6:0:0:0:0:0:ffff:192.0.2.1]", mapped: true},
+		{label: "double-compression", input: "user@[IPv6:2001::db8::1]", err: emailvalidator.ErrInvalidIPv6Literal},
+		{label: "too-many-groups", input: "user@[IPv6:1:2:3:4:5:6:7:8:9]", err: emailvalidator.ErrInvalidIPv6Literal},
+		{label: "group-too-long", input: "user@[IPv6:2001:0db8a::1]", err: emailvalidator.ErrInvalidIPv6Literal},
+		{label: "bare-ipv4", input: "user@[IPv6:192.0.2.1]", err: emailvalidator.ErrInvalidIPv6Literal},
+		{label: "zone", input: "user@[IPv6:fe80::1%eth0]", err: emailvalidator.ErrInvalidAddressLiteral},
+	}
+
+	for _, step := range steps {
+		t.Run(step.label, func(t *testing.T) {
+			res, err := emailvalidator.BuildResult(step.input)
+			if step.err != nil {
+				if !errors.Is(err, step.err) {
+					t.Errorf("expected %v, saw %v", step.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !res.LiteralAddr.Is6() {
+				t.Errorf("expected LiteralAddr to be IPv6, saw %v", res.LiteralAddr)
+			}
+			if res.LiteralAddr.Is4In6() != step.mapped {
+				t.Errorf("expected Is4In6 %t, saw %t", step.mapped, res.LiteralAddr.Is4In6())
+			}
+		})
+	}
+}
+
+func TestBuildResult_LiteralDtext(t *testing.T) {
+	for _, input := range []string{
+		"user@[IPv6: 2001 ::1]",
+		"user@[192.0.2.1 ]",
+		"user@[192.0.[2].1]",
+		"user@[192.0.2\\.1]",
+		"user@[192.0.2.1\x01]",
+	} {
+		if _, err := emailvalidator.BuildResult(input); !errors.Is(err, emailvalidator.ErrInvalidLiteralCharacter) {
+			t.Errorf("%q: expected ErrInvalidLiteralCharacter, saw %v", input, err)
+		}
+	}
+
+	// dtext includes characters not otherwise allowed in a domain
+	if _, err := emailvalidator.BuildResult("user@[x-custom:a!b%c]"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
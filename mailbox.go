@@ -0,0 +1,74 @@
+package emailvalidator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// checkMailbox populates the Mailbox and SubAddress fields of res, returning any errors seen while applying the
+// configured mailbox rules.  Sub-address issues configured as warnings are added to res.Warnings.
+func checkMailbox(res *Result, opts *ParseOptions) []error {
+	var errs []error
+
+	res.Mailbox = res.Local
+
+	// quoted locals are left whole, as a separator within the quoted text is not a sub-address marker.
+	if opts.SubAddressSeparators != "" && !res.Quoted {
+		if idx := strings.IndexAny(res.Local, opts.SubAddressSeparators); idx >= 0 {
+			res.Mailbox = res.Local[:idx]
+			res.SubAddress = res.Local[idx+1:]
+
+			var issues []error
+			if strings.Trim(res.SubAddress, opts.SubAddressSeparators) == "" {
+				issues = append(issues, fmt.Errorf("%w: %q", ErrEmptySubAddress, res.Local))
+			}
+			if run := longestRun(res.Local, opts.SubAddressSeparators); opts.MaxConsecutiveSeparators > 0 && run > opts.MaxConsecutiveSeparators {
+				issues = append(issues, fmt.Errorf("%w: %d > %d", ErrConsecutiveSeparators, run, opts.MaxConsecutiveSeparators))
+			}
+			for _, issue := range issues {
+				switch opts.SubAddressSeverity {
+				case SeverityIgnore:
+				case SeverityWarning:
+					res.Warnings = append(res.Warnings, newParseError(issue, -1, "", SegmentLocal))
+				default:
+					errs = append(errs, newParseError(issue, -1, "", SegmentLocal))
+				}
+			}
+		}
+	}
+
+	if opts.MailboxAllowedCharacters != "" {
+		for i := 0; i < len(res.Mailbox); i++ {
+			if strings.IndexByte(opts.MailboxAllowedCharacters, res.Mailbox[i]) == -1 {
+				err := fmt.Errorf("%w: %q at position %d in mailbox", ErrMailboxCharacterNotAllowed, res.Mailbox[i], i)
+				errs = append(errs, newParseError(err, -1, string(res.Mailbox[i]), SegmentLocal))
+			}
+		}
+	}
+
+	if l := len(res.Mailbox); opts.MailboxMinLength > 0 && l < opts.MailboxMinLength {
+		err := fmt.Errorf("%w: %d < %d", ErrMailboxTooShort, l, opts.MailboxMinLength)
+		errs = append(errs, newParseError(err, -1, "", SegmentLocal))
+	} else if opts.MailboxMaxLength > 0 && l > opts.MailboxMaxLength {
+		err := fmt.Errorf("%w: %d > %d", ErrMailboxTooLong, l, opts.MailboxMaxLength)
+		errs = append(errs, newParseError(err, -1, "", SegmentLocal))
+	}
+
+	return errs
+}
+
+// longestRun returns the length of the longest run of consecutive bytes in s that are contained within chars
+func longestRun(s, chars string) int {
+	var longest, current int
+	for i := 0; i < len(s); i++ {
+		if strings.IndexByte(chars, s[i]) == -1 {
+			current = 0
+			continue
+		}
+		current++
+		if current > longest {
+			longest = current
+		}
+	}
+	return longest
+}
@@ -0,0 +1,187 @@
+package emailvalidator
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultLocale is the locale used when no other catalog matches
+const DefaultLocale = "en"
+
+// Messages maps error codes to human-readable messages suitable for display to end users
+type Messages map[ErrorCode]string
+
+var englishMessages = Messages{
+	CodeEmptyInput:                      "Please enter an email address.",
+	CodeUnexpectedNonGraphicCharacter:   "The address contains an invisible or control character.",
+	CodePossibleHeaderInjection:         "The address contains a line break.",
+	CodeBidiControlCharacter:            "The address contains a hidden character that changes the direction of text.",
+	CodeInvisibleCharacter:              "The address contains a hidden character, which may have been copied along with it.",
+	CodePercentDecoded:                  "The address was URL-encoded, and has been decoded.",
+	CodeHTMLDecoded:                     "The address contained HTML entities, which have been decoded.",
+	CodeDisallowedLocalCharacter:        "The part before the @ contains a character that is not accepted here.",
+	CodeInvalidLiteralCharacter:         "The bracketed address after the @ contains a space, bracket, or control character.",
+	CodeUnexpectedCharacter:             "The address contains a character that is not allowed.",
+	CodeInvalidUnquotedSequence:         "The address contains a character that is only allowed inside quotes.",
+	CodeUnexpectedCharactersAfterDomain: "The address has unexpected text after the domain.",
+	CodeZeroLengthLocalPart:             "The address is missing the part before the @.",
+	CodeLocalPartTooLong:                "The part before the @ is too long.",
+	CodeZeroLengthDomain:                "The address is missing a domain.",
+	CodeDomainTooLong:                   "The domain is too long.",
+	CodeMailboxCharacterNotAllowed:      "The mailbox name contains a character this provider does not allow.",
+	CodeMailboxTooShort:                 "The mailbox name is too short.",
+	CodeMailboxTooLong:                  "The mailbox name is too long.",
+	CodeEmptySubAddress:                 "The address has an empty tag after the separator.",
+	CodeConsecutiveSeparators:           "The address has too many separators in a row.",
+	CodeInvalidAddressLiteral:           "The bracketed address after the @ is not valid.",
+	CodeInvalidIPv6Literal:              "The bracketed IPv6 address after the @ is not valid.",
+	CodeUnregisteredLiteralTag:          "The bracketed address after the @ uses an unsupported type.",
+	CodeRoleAccount:                     "The address belongs to a team or role rather than a person.",
+	CodeUppercaseLocal:                  "The part before the @ contains capital letters, which some providers treat as different addresses.",
+	CodeEngineRejected:                  "The address is not valid.",
+	CodeUnsafeAddress:                   "The address uses formatting that is not accepted here.",
+	CodePolicyViolation:                 "The address is not allowed.",
+	CodeCSVColumnMissing:                "The row does not contain an address.",
+	CodeRemoteValidator:                 "The address could not be validated.",
+	CodeSinkDelivery:                    "The result could not be delivered.",
+	CodeInvalidEnvelope:                 "The request could not be read.",
+	CodeLineTooLong:                     "The line is too long to contain an address.",
+	CodeUnknown:                         "The address is invalid.",
+}
+
+var (
+	catalogsMu sync.RWMutex
+	catalogs   = map[string]Messages{
+		DefaultLocale: englishMessages,
+	}
+)
+
+// RegisterMessages adds or extends the catalog for locale, e.g. "de" or "pt-BR".  Codes missing from a catalog fall
+// back to the parent locale, then to English.
+func RegisterMessages(locale string, msgs Messages) {
+	locale = normalizeLocale(locale)
+
+	catalogsMu.Lock()
+	defer catalogsMu.Unlock()
+
+	catalog, ok := catalogs[locale]
+	if !ok {
+		catalog = make(Messages, len(msgs))
+		catalogs[locale] = catalog
+	}
+	for code, msg := range msgs {
+		catalog[code] = msg
+	}
+}
+
+// MessageFor returns the message for code in locale, falling back first to the locale's parent (e.g. "pt" for
+// "pt-BR") and then to English.
+func MessageFor(code ErrorCode, locale string) string {
+	catalogsMu.RLock()
+	defer catalogsMu.RUnlock()
+
+	for _, candidate := range []string{normalizeLocale(locale), parentLocale(locale), DefaultLocale} {
+		if msg, ok := catalogs[candidate][code]; ok {
+			return msg
+		}
+	}
+	return englishMessages[CodeUnknown]
+}
+
+// WithLocale sets the locale used to populate ParseError.Message
+func WithLocale(locale string) OptFunc {
+	return func(opt *ParseOptions) {
+		opt.Locale = locale
+	}
+}
+
+// WithMessages sets per-call message overrides, consulted before any registered catalog
+func WithMessages(msgs Messages) OptFunc {
+	return func(opt *ParseOptions) {
+		opt.Messages = msgs
+	}
+}
+
+// MessageFormatter renders the Message of a ParseError.  Implement this to control the tone and phrasing of
+// diagnostics beyond what per-code catalog strings allow.
+type MessageFormatter interface {
+	FormatMessage(pe ParseError, locale string) string
+}
+
+// MessageFormatterFunc adapts a function to the MessageFormatter interface
+type MessageFormatterFunc func(pe ParseError, locale string) string
+
+func (fn MessageFormatterFunc) FormatMessage(pe ParseError, locale string) string {
+	return fn(pe, locale)
+}
+
+// TemplateFormatter renders messages from per-code templates, falling back to the registered catalog for codes it
+// does not contain.  Templates and catalog messages alike may contain the placeholders {code}, {char}, {segment}, and
+// {pos}, e.g. "{char} is not allowed in the {segment} at position {pos}".
+type TemplateFormatter map[ErrorCode]string
+
+func (tf TemplateFormatter) FormatMessage(pe ParseError, locale string) string {
+	if tmpl, ok := tf[pe.Code]; ok {
+		return ExpandMessage(tmpl, pe)
+	}
+	return ExpandMessage(MessageFor(pe.Code, locale), pe)
+}
+
+// ExpandMessage replaces the {code}, {char}, {segment}, and {pos} placeholders in tmpl with values from pe.  {pos} is
+// replaced with an empty string for errors that have no position.
+func ExpandMessage(tmpl string, pe ParseError) string {
+	if !strings.Contains(tmpl, "{") {
+		return tmpl
+	}
+	pos := ""
+	if pe.Position >= 0 {
+		pos = strconv.Itoa(pe.Position)
+	}
+	return strings.NewReplacer(
+		"{code}", string(pe.Code),
+		"{char}", pe.Char,
+		"{segment}", string(pe.Segment),
+		"{pos}", pos,
+	).Replace(tmpl)
+}
+
+// WithMessageFormatter sets the MessageFormatter used to populate ParseError.Message
+func WithMessageFormatter(formatter MessageFormatter) OptFunc {
+	return func(opt *ParseOptions) {
+		opt.MessageFormatter = formatter
+	}
+}
+
+// localizeErrors populates the Message field of every ParseError in errs per opts
+func localizeErrors(errs []error, opts *ParseOptions) {
+	for i, err := range errs {
+		if pe, ok := err.(ParseError); ok {
+			errs[i] = localize(pe, opts)
+		}
+	}
+}
+
+// localize returns pe with its Message field populated per opts
+func localize(pe ParseError, opts *ParseOptions) ParseError {
+	if opts.MessageFormatter != nil {
+		pe.Message = opts.MessageFormatter.FormatMessage(pe, opts.Locale)
+	} else if msg, ok := opts.Messages[pe.Code]; ok {
+		pe.Message = ExpandMessage(msg, pe)
+	} else {
+		pe.Message = ExpandMessage(MessageFor(pe.Code, opts.Locale), pe)
+	}
+	return pe
+}
+
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+}
+
+func parentLocale(locale string) string {
+	locale = normalizeLocale(locale)
+	if idx := strings.IndexByte(locale, '-'); idx > 0 {
+		return locale[:idx]
+	}
+	return locale
+}
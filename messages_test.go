@@ -0,0 +1,96 @@
+package emailvalidator_test
+
+import (
+	"testing"
+
+	emailvalidator "github.com/dcarbone/go-email-validator"
+)
+
+func TestMessages(t *testing.T) {
+	emailvalidator.RegisterMessages("de", emailvalidator.Messages{
+		emailvalidator.CodeZeroLengthDomain: "Der Adresse fehlt eine Domain.",
+	})
+
+	steps := []struct {
+		label    string
+		opts     []emailvalidator.OptFunc
+		expected string
+	}{
+		{
+			label:    "default",
+			expected: "The address is missing a domain.",
+		},
+		{
+			label:    "german",
+			opts:     []emailvalidator.OptFunc{emailvalidator.WithLocale("de")},
+			expected: "Der Adresse fehlt eine Domain.",
+		},
+		{
+			label:    "german-region-fallback",
+			opts:     []emailvalidator.OptFunc{emailvalidator.WithLocale("de_AT")},
+			expected: "Der Adresse fehlt eine Domain.",
+		},
+		{
+			label:    "unknown-locale-fallback",
+			opts:     []emailvalidator.OptFunc{emailvalidator.WithLocale("xx")},
+			expected: "The address is missing a domain.",
+		},
+		{
+			label: "override",
+			opts: []emailvalidator.OptFunc{
+				emailvalidator.WithLocale("de"),
+				emailvalidator.WithMessages(emailvalidator.Messages{emailvalidator.CodeZeroLengthDomain: "custom"}),
+			},
+			expected: "custom",
+		},
+	}
+
+	for _, step := range steps {
+		t.Run(step.label, func(t *testing.T) {
+			_, err := emailvalidator.BuildResult("abc.example.com", step.opts...)
+			perrs := emailvalidator.ErrorsOf(err)
+			if len(perrs) != 1 {
+				t.Fatalf("expected 1 error, saw %v", perrs)
+			}
+			if perrs[0].Message != step.expected {
+				t.Errorf("expected %q, saw %q", step.expected, perrs[0].Message)
+			}
+		})
+	}
+}
+
+func TestMessages_AllCodesHaveEnglish(t *testing.T) {
+	for _, code := range emailvalidator.Codes() {
+		if emailvalidator.MessageFor(code, emailvalidator.DefaultLocale) == emailvalidator.MessageFor(emailvalidator.CodeUnknown, "") &&
+			code != emailvalidator.CodeUnknown {
+			t.Errorf("code %q has no english message", code)
+		}
+	}
+}
+
+func TestTemplateFormatter(t *testing.T) {
+	_, err := emailvalidator.BuildResult("a b@example.com", emailvalidator.WithMessageFormatter(
+		emailvalidator.TemplateFormatter{
+			emailvalidator.CodeInvalidUnquotedSequence: "{char} is not allowed in the {segment} at position {pos}",
+		},
+	))
+	perrs := emailvalidator.ErrorsOf(err)
+	if len(perrs) != 1 {
+		t.Fatalf("expected 1 error, saw %v", perrs)
+	}
+	if expected := "  is not allowed in the local at position 1"; perrs[0].Message != expected {
+		t.Errorf("expected %q, saw %q", expected, perrs[0].Message)
+	}
+}
+
+func TestMessageFormatterFunc(t *testing.T) {
+	_, err := emailvalidator.BuildResult("abc.example.com", emailvalidator.WithMessageFormatter(
+		emailvalidator.MessageFormatterFunc(func(pe emailvalidator.ParseError, _ string) string {
+			return "oops: " + string(pe.Code)
+		}),
+	))
+	perrs := emailvalidator.ErrorsOf(err)
+	if len(perrs) != 1 || perrs[0].Message != "oops: zero_length_domain" {
+		t.Errorf("unexpected errors: %v", perrs)
+	}
+}
@@ -0,0 +1,103 @@
+package emailvalidator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// MaxFieldBodyBytes is the largest request body ValidateFields will read; larger bodies are refused with a 413
+const MaxFieldBodyBytes = 1 << 20
+
+// ValidateFields wraps next, validating each of fields within incoming requests with opts before next is called.
+// Fields are read from the top-level string members of JSON request bodies, or from form values otherwise.  If any
+// field is invalid, a 422 response containing a ProblemDetails is written and next is not called.  Bodies larger than
+// MaxFieldBodyBytes are refused with a 413 response.
+//
+// Fields absent from the request are not validated; requiring them is left to next.  The request body remains
+// readable by next.
+func ValidateFields(next http.Handler, fields []string, opts ...OptFunc) http.Handler {
+	v := NewValidator(opts...)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.Body = http.MaxBytesReader(w, r.Body, MaxFieldBodyBytes)
+		values, err := fieldValues(r, fields)
+		if err != nil {
+			status := http.StatusBadRequest
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				status = http.StatusRequestEntityTooLarge
+			}
+			writeProblemDetails(w, ProblemDetails{
+				Type:   "about:blank",
+				Title:  http.StatusText(status),
+				Status: status,
+				Detail: err.Error(),
+			})
+			return
+		}
+
+		invalid := make(map[string]ErrorList)
+		for field, value := range values {
+			if _, err = v.Parse(value); err != nil {
+				invalid[field] = ErrorListOf(err)
+			}
+		}
+		if len(invalid) > 0 {
+			writeProblemDetails(w, ProblemDetails{
+				Type:          "about:blank",
+				Title:         http.StatusText(http.StatusUnprocessableEntity),
+				Status:        http.StatusUnprocessableEntity,
+				Detail:        "one or more email addresses are invalid",
+				InvalidFields: invalid,
+			})
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// fieldValues returns the value of each of fields present within r, restoring r's body if it must be read
+func fieldValues(r *http.Request, fields []string) (map[string]string, error) {
+	values := make(map[string]string, len(fields))
+
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		if err := r.ParseForm(); err != nil {
+			return nil, err
+		}
+		for _, field := range fields {
+			if vs, ok := r.Form[field]; ok && len(vs) > 0 {
+				values[field] = vs[0]
+			}
+		}
+		return values, nil
+	}
+
+	b, err := io.ReadAll(r.Body)
+	_ = r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+
+	var body map[string]json.RawMessage
+	if err = json.Unmarshal(b, &body); err != nil {
+		return nil, err
+	}
+	for _, field := range fields {
+		raw, ok := body[field]
+		if !ok {
+			continue
+		}
+		var value string
+		if err = json.Unmarshal(raw, &value); err != nil {
+			// non-string values can never be valid addresses
+			value = string(raw)
+		}
+		values[field] = value
+	}
+	return values, nil
+}
@@ -0,0 +1,79 @@
+package emailvalidator_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	emailvalidator "github.com/dcarbone/go-email-validator"
+)
+
+func TestValidateFields(t *testing.T) {
+	var body string
+	handler := emailvalidator.ValidateFields(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			b, _ := io.ReadAll(r.Body)
+			body = string(b)
+			w.WriteHeader(http.StatusNoContent)
+		}),
+		[]string{"email", "backup_email"},
+	)
+
+	steps := []struct {
+		label   string
+		ctype   string
+		body    string
+		code    int
+		invalid []string
+	}{
+		{label: "form-ok", ctype: "application/x-www-form-urlencoded", body: url.Values{"email": {"jane@example.com"}}.Encode(), code: http.StatusNoContent},
+		{label: "form-absent", ctype: "application/x-www-form-urlencoded", body: "name=jane", code: http.StatusNoContent},
+		{label: "form-invalid", ctype: "application/x-www-form-urlencoded", body: url.Values{"email": {"jane@@example.com"}}.Encode(), code: http.StatusUnprocessableEntity, invalid: []string{"email"}},
+		{label: "json-ok", ctype: "application/json", body: `{"email":"jane@example.com","name":"jane"}`, code: http.StatusNoContent},
+		{label: "json-invalid", ctype: "application/json", body: `{"email":"jane@example.com","backup_email":"jane doe@example.com"}`, code: http.StatusUnprocessableEntity, invalid: []string{"backup_email"}},
+		{label: "json-non-string", ctype: "application/json", body: `{"email":12}`, code: http.StatusUnprocessableEntity, invalid: []string{"email"}},
+		{label: "json-malformed", ctype: "application/json", body: `{"email":`, code: http.StatusBadRequest},
+		{label: "json-too-large", ctype: "application/json", body: `{"email":"jane@example.com","pad":"` + strings.Repeat("x", emailvalidator.MaxFieldBodyBytes) + `"}`, code: http.StatusRequestEntityTooLarge},
+		{label: "form-too-large", ctype: "application/x-www-form-urlencoded", body: "email=jane%40example.com&pad=" + strings.Repeat("x", emailvalidator.MaxFieldBodyBytes), code: http.StatusRequestEntityTooLarge},
+	}
+
+	for _, step := range steps {
+		t.Run(step.label, func(t *testing.T) {
+			body = ""
+			req := httptest.NewRequest(http.MethodPost, "/signup", strings.NewReader(step.body))
+			req.Header.Set("Content-Type", step.ctype)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != step.code {
+				t.Fatalf("expected status %d, saw %d", step.code, rec.Code)
+			}
+			if step.code == http.StatusNoContent {
+				if step.ctype == "application/json" && body != step.body {
+					t.Errorf("expected body to remain readable, saw %q", body)
+				}
+				return
+			}
+
+			if ct := rec.Header().Get("Content-Type"); ct != "application/problem+json" {
+				t.Errorf("unexpected content type %q", ct)
+			}
+			var problem emailvalidator.ProblemDetails
+			if err := json.NewDecoder(rec.Body).Decode(&problem); err != nil {
+				t.Fatalf("error decoding problem: %v", err)
+			}
+			if problem.Status != step.code || len(problem.InvalidFields) != len(step.invalid) {
+				t.Errorf("unexpected problem: %+v", problem)
+			}
+			for _, field := range step.invalid {
+				if len(problem.InvalidFields[field]) == 0 {
+					t.Errorf("expected errors for %q", field)
+				}
+			}
+		})
+	}
+}
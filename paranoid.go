@@ -0,0 +1,63 @@
+package emailvalidator
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ParanoidMaxLength is the maximum input length accepted by PresetParanoid, per the 254 character limit on addresses
+// imposed by RFC 5321's 256 character path limit
+const ParanoidMaxLength = 254
+
+var (
+	ErrUnsafeAddress = errors.New("address uses a construct rejected by paranoid profile")
+)
+
+// PresetParanoid configures the profile most appropriate for untrusted web input, e.g. signup forms: comments,
+// quoting, address literals, source routes, control characters, non-ASCII characters, and inputs longer than
+// ParanoidMaxLength are all rejected with ErrUnsafeAddress.  Only the first error seen is returned.
+func PresetParanoid(opt *ParseOptions) {
+	opt.Paranoid = true
+	opt.StrictDotAtom = true
+	opt.RejectEmptyLocal = true
+	if opt.BehaviorVersion < BehaviorVersion2 {
+		opt.BehaviorVersion = BehaviorVersion2
+	}
+}
+
+// checkParanoid screens email for constructs that are valid, but have no place in an address typed into a web form.
+// It returns nil if none were seen.
+func checkParanoid(email string) *ParseError {
+	if l := len(email); l > ParanoidMaxLength {
+		pe := newParseError(fmt.Errorf("%w: length %d exceeds %d", ErrUnsafeAddress, l, ParanoidMaxLength), -1, "", SegmentLocal)
+		return &pe
+	}
+
+	var (
+		inDomain bool
+		reason   string
+	)
+	for i := 0; i < len(email); i++ {
+		switch c := email[i]; {
+		case c < 32 || c == 127:
+			reason = "control character"
+		case c > 127:
+			reason = "non-ascii character"
+		case c == '(' || c == ')':
+			reason = "comment"
+		case c == '"' || c == '\\':
+			reason = "quoting"
+		case c == '[' || c == ']':
+			reason = "address literal"
+		case c == '@' && i == 0, c == ':' && !inDomain:
+			reason = "source route"
+		case c == '@':
+			inDomain = true
+		}
+		if reason != "" {
+			pe := newParseError(fmt.Errorf("%w: %s at position %d", ErrUnsafeAddress, reason, i), i, email[i:i+1], currentSegment(false, inDomain))
+			return &pe
+		}
+	}
+	return nil
+}
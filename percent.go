@@ -0,0 +1,39 @@
+package emailvalidator
+
+import (
+	"errors"
+	"net/url"
+)
+
+var (
+	ErrPercentDecoded = errors.New("percent-encoded input decoded")
+)
+
+// hasPercentEscape returns true if s contains a "%" followed by two hexadecimal digits, as is the case with addresses
+// taken verbatim from URLs, e.g. "user%40example.com".  "%" is valid within a local part, so this is only a signal.
+func hasPercentEscape(s string) bool {
+	for i := 0; i+2 < len(s); i++ {
+		if s[i] == '%' && isHexDigit(s[i+1]) && isHexDigit(s[i+2]) {
+			return true
+		}
+	}
+	return false
+}
+
+func isHexDigit(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+// decodePercent returns email with percent-encoding removed, and an ErrPercentDecoded warning, if email contains
+// percent-encoded characters and can be decoded in its entirety.  Otherwise, email is returned as-is.
+func decodePercent(email string) (string, *ParseError) {
+	if !hasPercentEscape(email) {
+		return email, nil
+	}
+	decoded, err := url.PathUnescape(email)
+	if err != nil || decoded == email {
+		return email, nil
+	}
+	pe := newParseError(ErrPercentDecoded, -1, "", "")
+	return decoded, &pe
+}
@@ -0,0 +1,178 @@
+package emailvalidator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrPolicyViolation = errors.New("policy violation")
+)
+
+// Rule is a single policy constraint evaluated against an already-parsed Result.  Rules are distinct from syntax
+// checks: an address may be perfectly valid per the RFCs and still be rejected by an organization's policy.
+type Rule interface {
+	// Name is the label attached to any Violation produced by this Rule
+	Name() string
+
+	// Check returns a non-nil error describing why res does not satisfy this Rule
+	Check(res Result) error
+}
+
+type ruleFunc struct {
+	name string
+	fn   func(Result) error
+}
+
+func (r ruleFunc) Name() string {
+	return r.name
+}
+
+func (r ruleFunc) Check(res Result) error {
+	return r.fn(res)
+}
+
+// RuleFunc creates a named Rule from fn
+func RuleFunc(name string, fn func(Result) error) Rule {
+	return ruleFunc{name: name, fn: fn}
+}
+
+// Violation is a labeled policy failure.  It wraps ErrPolicyViolation.
+type Violation struct {
+	Rule string
+	Err  error
+}
+
+func (v Violation) Error() string {
+	return fmt.Sprintf("%s: %s: %v", ErrPolicyViolation, v.Rule, v.Err)
+}
+
+func (v Violation) Unwrap() []error {
+	return []error{ErrPolicyViolation, v.Err}
+}
+
+// Policy is an ordered set of Rules
+type Policy []Rule
+
+// Evaluate checks res against every Rule in p, returning all seen violations.
+func (p Policy) Evaluate(res Result) []Violation {
+	var violations []Violation
+	for _, rule := range p {
+		if err := rule.Check(res); err != nil {
+			violations = append(violations, Violation{Rule: rule.Name(), Err: err})
+		}
+	}
+	return violations
+}
+
+// Check parses email and, if it is syntactically valid, evaluates p against the result.  Syntax errors are returned
+// as err and are never reported as violations.
+func (p Policy) Check(email string, opts ...OptFunc) (Result, []Violation, error) {
+	res, err := BuildResult(email, opts...)
+	if err != nil {
+		return res, nil, err
+	}
+	return res, p.Evaluate(res), nil
+}
+
+// MinLocalLength requires the local part to be at least n characters long
+func MinLocalLength(n int) Rule {
+	return RuleFunc("min-local-length", func(res Result) error {
+		if l := len(res.Local); l < n {
+			return fmt.Errorf("local part length %d is below minimum %d", l, n)
+		}
+		return nil
+	})
+}
+
+// MaxLocalLength requires the local part to be at most n characters long, which may be stricter than the RFC limit of
+// LocalPartMaxLength
+func MaxLocalLength(n int) Rule {
+	return RuleFunc("max-local-length", func(res Result) error {
+		if l := len(res.Local); l > n {
+			return fmt.Errorf("local part length %d exceeds maximum %d", l, n)
+		}
+		return nil
+	})
+}
+
+// OnDomains scopes rule to addresses whose domain is equal to, or a subdomain of, one of suffixes.  It keeps rule's
+// name, e.g. OnDomains(MinLocalLength(2), "gmail.com", "outlook.com") to reject 1-character locals on consumer
+// domains only.
+func OnDomains(rule Rule, suffixes ...string) Rule {
+	return RuleFunc(rule.Name(), func(res Result) error {
+		if !hasDomainSuffix(res.Domain, suffixes) {
+			return nil
+		}
+		return rule.Check(res)
+	})
+}
+
+// BannedLocalWords rejects local parts containing any of words, compared case-insensitively
+func BannedLocalWords(words ...string) Rule {
+	return RuleFunc("banned-local-words", func(res Result) error {
+		local := strings.ToLower(res.Local)
+		for _, word := range words {
+			if strings.Contains(local, strings.ToLower(word)) {
+				return fmt.Errorf("local part contains banned word %q", word)
+			}
+		}
+		return nil
+	})
+}
+
+// RequiredDomainSuffix requires the domain to be equal to, or a subdomain of, one of suffixes
+func RequiredDomainSuffix(suffixes ...string) Rule {
+	return RuleFunc("required-domain-suffix", func(res Result) error {
+		if hasDomainSuffix(res.Domain, suffixes) {
+			return nil
+		}
+		return fmt.Errorf("domain %q does not match any of %v", res.Domain, suffixes)
+	})
+}
+
+// hasDomainSuffix returns true if domain is equal to, or a subdomain of, one of suffixes, compared case-insensitively
+func hasDomainSuffix(domain string, suffixes []string) bool {
+	domain = strings.ToLower(domain)
+	for _, suffix := range suffixes {
+		suffix = strings.ToLower(strings.TrimPrefix(suffix, "."))
+		if domain == suffix || strings.HasSuffix(domain, "."+suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// BannedTLDs rejects domains whose final label is one of tlds, compared case-insensitively
+func BannedTLDs(tlds ...string) Rule {
+	return RuleFunc("banned-tlds", func(res Result) error {
+		if res.LiteralDomain {
+			return nil
+		}
+		domain := strings.ToLower(res.Domain)
+		tld := domain[strings.LastIndexByte(domain, '.')+1:]
+		for _, banned := range tlds {
+			if tld == strings.ToLower(strings.TrimPrefix(banned, ".")) {
+				return fmt.Errorf("top-level domain %q is banned", tld)
+			}
+		}
+		return nil
+	})
+}
+
+// AllowedCommentPlacements rejects addresses containing a comment in any placement other than placements
+func AllowedCommentPlacements(placements ...CommentPlacement) Rule {
+	return RuleFunc("allowed-comment-placements", func(res Result) error {
+	next:
+		for _, c := range res.Comments {
+			for _, placement := range placements {
+				if c.Placement == placement {
+					continue next
+				}
+			}
+			return fmt.Errorf("comment %q at position %d is not allowed %s", c.Text, c.Position, strings.ReplaceAll(string(c.Placement), "_", " "))
+		}
+		return nil
+	})
+}
@@ -0,0 +1,129 @@
+package emailvalidator_test
+
+import (
+	"errors"
+	"testing"
+
+	emailvalidator "github.com/dcarbone/go-email-validator"
+)
+
+func TestPolicy(t *testing.T) {
+	policy := emailvalidator.Policy{
+		emailvalidator.MinLocalLength(3),
+		emailvalidator.BannedLocalWords("admin"),
+		emailvalidator.RequiredDomainSuffix("example.com"),
+		emailvalidator.BannedTLDs("zip"),
+	}
+
+	steps := []struct {
+		label string
+		input string
+		rules []string
+	}{
+		{
+			label: "ok",
+			input: "employee@mail.example.com",
+		},
+		{
+			label: "short-local",
+			input: "ab@example.com",
+			rules: []string{"min-local-length"},
+		},
+		{
+			label: "banned-word-and-domain",
+			input: "SysAdmin@example.zip",
+			rules: []string{"banned-local-words", "required-domain-suffix", "banned-tlds"},
+		},
+		{
+			label: "suffix-not-subdomain",
+			input: "employee@notexample.com",
+			rules: []string{"required-domain-suffix"},
+		},
+	}
+
+	for _, step := range steps {
+		t.Run(step.label, func(t *testing.T) {
+			_, violations, err := policy.Check(step.input)
+			if err != nil {
+				t.Fatalf("unexpected syntax error: %v", err)
+			}
+			if len(violations) != len(step.rules) {
+				t.Fatalf("expected violations %v, saw %v", step.rules, violations)
+			}
+			for i, v := range violations {
+				if v.Rule != step.rules[i] {
+					t.Errorf("expected violation %q, saw %q", step.rules[i], v.Rule)
+				}
+				if !errors.Is(v, emailvalidator.ErrPolicyViolation) {
+					t.Errorf("expected violation to wrap ErrPolicyViolation")
+				}
+			}
+		})
+	}
+}
+
+func TestPolicy_SyntaxErrorIsNotViolation(t *testing.T) {
+	_, violations, err := emailvalidator.Policy{emailvalidator.MinLocalLength(100)}.Check("abc.example.com")
+	if err == nil {
+		t.Error("expected syntax error")
+	}
+	if len(violations) != 0 {
+		t.Errorf("expected no violations, saw %v", violations)
+	}
+}
+
+func TestAllowedCommentPlacements(t *testing.T) {
+	policy := emailvalidator.Policy{
+		emailvalidator.AllowedCommentPlacements(emailvalidator.CommentAfterDomain),
+	}
+
+	for input, allowed := range map[string]bool{
+		"user@example.com":        true,
+		"user@example.com (work)": true,
+		"(work)user@example.com":  false,
+		"user@(work)example.com":  false,
+	} {
+		_, violations, err := policy.Check(input)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", input, err)
+		}
+		if allowed != (len(violations) == 0) {
+			t.Errorf("%s: expected allowed=%t, saw violations %v", input, allowed, violations)
+		}
+	}
+}
+
+func TestLocalLengthPolicy(t *testing.T) {
+	policy := emailvalidator.Policy{
+		emailvalidator.OnDomains(emailvalidator.MinLocalLength(2), "gmail.com", "outlook.com"),
+		emailvalidator.MaxLocalLength(20),
+	}
+
+	steps := []struct {
+		input string
+		rules []string
+	}{
+		{input: "jane@gmail.com"},
+		{input: "j@example.com"},
+		{input: "j@gmail.com", rules: []string{"min-local-length"}},
+		{input: "j@eu.Outlook.com", rules: []string{"min-local-length"}},
+		{input: "abcdefghijklmnopqrstu@example.com", rules: []string{"max-local-length"}},
+	}
+
+	for _, step := range steps {
+		t.Run(step.input, func(t *testing.T) {
+			_, violations, err := policy.Check(step.input)
+			if err != nil {
+				t.Fatalf("unexpected syntax error: %v", err)
+			}
+			if len(violations) != len(step.rules) {
+				t.Fatalf("expected violations %v, saw %v", step.rules, violations)
+			}
+			for i, v := range violations {
+				if v.Rule != step.rules[i] {
+					t.Errorf("expected violation %q, saw %q", step.rules[i], v.Rule)
+				}
+			}
+		})
+	}
+}
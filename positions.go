@@ -0,0 +1,59 @@
+package emailvalidator
+
+// DefaultMaxPositionRuns is the maximum number of PositionRuns recorded across all characters when tracking character
+// positions, unless otherwise configured
+const DefaultMaxPositionRuns = 256
+
+// PositionRun is a run of consecutive offsets at which a character was seen, e.g. {Start: 2, Len: 3} for offsets
+// 2, 3, and 4
+type PositionRun struct {
+	Start int `json:"start"`
+	Len   int `json:"len"`
+}
+
+// Positions is the run-length encoded list of offsets at which a character was seen, in ascending order.  Encoding as
+// runs keeps memory bounded on adversarial inputs, such as a long sequence of the same character.
+type Positions []PositionRun
+
+// Offsets returns every offset within p
+func (p Positions) Offsets() []int {
+	out := make([]int, 0, p.Count())
+	for _, run := range p {
+		for i := 0; i < run.Len; i++ {
+			out = append(out, run.Start+i)
+		}
+	}
+	return out
+}
+
+// Count returns the number of offsets within p
+func (p Positions) Count() int {
+	var n int
+	for _, run := range p {
+		n += run.Len
+	}
+	return n
+}
+
+func (opt *ParseOptions) maxPositionRuns() int {
+	if opt.MaxPositionRuns > 0 {
+		return opt.MaxPositionRuns
+	}
+	return DefaultMaxPositionRuns
+}
+
+// trackPosition records that chr was seen at offset i.  Extending an existing run is always possible, but once
+// *budget new runs have been recorded, further runs are dropped and res.CharacterPositionsTruncated is set.
+func trackPosition(res *Result, chr string, i int, budget *int) {
+	runs := res.CharacterPositions[chr]
+	if l := len(runs); l > 0 && runs[l-1].Start+runs[l-1].Len == i {
+		runs[l-1].Len++
+		return
+	}
+	if *budget <= 0 {
+		res.CharacterPositionsTruncated = true
+		return
+	}
+	*budget--
+	res.CharacterPositions[chr] = append(runs, PositionRun{Start: i, Len: 1})
+}
@@ -0,0 +1,41 @@
+package emailvalidator_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	emailvalidator "github.com/dcarbone/go-email-validator"
+)
+
+func TestCharacterPositions(t *testing.T) {
+	res, _ := emailvalidator.BuildResult("aab.ab@ex.com", emailvalidator.TrackCharacterPositions)
+
+	if runs := res.CharacterPositions["a"]; !reflect.DeepEqual(runs, emailvalidator.Positions{{Start: 0, Len: 2}, {Start: 4, Len: 1}}) {
+		t.Errorf("unexpected runs for %q: %v", "a", runs)
+	}
+	if offsets := res.CharacterPositions["."].Offsets(); !reflect.DeepEqual(offsets, []int{3, 9}) {
+		t.Errorf("unexpected offsets for %q: %v", ".", offsets)
+	}
+	if res.CharacterPositionsTruncated {
+		t.Error("expected positions to be complete")
+	}
+}
+
+func TestCharacterPositions_Pathological(t *testing.T) {
+	// a long run of a single byte is a single run
+	res, _ := emailvalidator.BuildResult(strings.Repeat("a", 1<<14), emailvalidator.TrackCharacterPositions)
+	if runs := res.CharacterPositions["a"]; len(runs) != 1 || runs.Count() != 1<<14 {
+		t.Errorf("expected a single run of %d, saw %d runs of %d", 1<<14, len(runs), runs.Count())
+	}
+
+	// alternating bytes are capped
+	res, _ = emailvalidator.BuildResult(strings.Repeat("ab", 1<<10), emailvalidator.TrackCharacterPositions,
+		func(opt *emailvalidator.ParseOptions) { opt.MaxPositionRuns = 10 })
+	if n := len(res.CharacterPositions["a"]) + len(res.CharacterPositions["b"]); n != 10 {
+		t.Errorf("expected 10 runs, saw %d", n)
+	}
+	if !res.CharacterPositionsTruncated {
+		t.Error("expected positions to be truncated")
+	}
+}
@@ -0,0 +1,38 @@
+package emailvalidator
+
+const (
+	alphaNumeric = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+)
+
+// PresetGmailRules configures the rules Google applies when creating a Gmail mailbox: letters, numbers, and periods,
+// between 6 and 30 characters, with "+" sub-addressing.
+func PresetGmailRules(opt *ParseOptions) {
+	opt.MailboxAllowedCharacters = alphaNumeric + "."
+	opt.MailboxMinLength = 6
+	opt.MailboxMaxLength = 30
+	opt.SubAddressSeparators = "+"
+}
+
+// PresetMicrosoft365Rules configures the rules Microsoft applies when creating an Outlook.com or Microsoft 365
+// mailbox: letters, numbers, periods, underscores, and hyphens, up to 64 characters, with "+" sub-addressing.
+func PresetMicrosoft365Rules(opt *ParseOptions) {
+	opt.MailboxAllowedCharacters = alphaNumeric + "._-"
+	opt.MailboxMinLength = 1
+	opt.MailboxMaxLength = 64
+	opt.SubAddressSeparators = "+"
+}
+
+// PresetICloudRules configures the rules Apple applies when creating an iCloud mailbox: letters, numbers, periods,
+// and underscores, between 3 and 20 characters, with "+" sub-addressing.
+func PresetICloudRules(opt *ParseOptions) {
+	opt.MailboxAllowedCharacters = alphaNumeric + "._"
+	opt.MailboxMinLength = 3
+	opt.MailboxMaxLength = 20
+	opt.SubAddressSeparators = "+"
+}
+
+// PresetStrictSMTP requires the local to take a form allowed in an SMTP envelope per RFC 5321: a Dot-string or a
+// single Quoted-string, with no comments within or around it.
+func PresetStrictSMTP(opt *ParseOptions) {
+	opt.StrictDotAtom = true
+}
@@ -0,0 +1,184 @@
+package emailvalidator_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	emailvalidator "github.com/dcarbone/go-email-validator"
+)
+
+func TestPresets(t *testing.T) {
+	steps := []struct {
+		label  string
+		preset emailvalidator.OptFunc
+		input  string
+		err    error
+	}{
+		{
+			label:  "gmail-ok",
+			preset: emailvalidator.PresetGmailRules,
+			input:  "first.last+news@gmail.com",
+		},
+		{
+			label:  "gmail-underscore",
+			preset: emailvalidator.PresetGmailRules,
+			input:  "first_last@gmail.com",
+			err:    emailvalidator.ErrMailboxCharacterNotAllowed,
+		},
+		{
+			label:  "gmail-short",
+			preset: emailvalidator.PresetGmailRules,
+			input:  "abc+longtagvalue@gmail.com",
+			err:    emailvalidator.ErrMailboxTooShort,
+		},
+		{
+			label:  "microsoft-hyphen",
+			preset: emailvalidator.PresetMicrosoft365Rules,
+			input:  "first-last@outlook.com",
+		},
+		{
+			label:  "microsoft-bang",
+			preset: emailvalidator.PresetMicrosoft365Rules,
+			input:  "first!last@outlook.com",
+			err:    emailvalidator.ErrMailboxCharacterNotAllowed,
+		},
+		{
+			label:  "icloud-long",
+			preset: emailvalidator.PresetICloudRules,
+			input:  "abcdefghijklmnopqrstuvwxyz@icloud.com",
+			err:    emailvalidator.ErrMailboxTooLong,
+		},
+	}
+
+	for _, step := range steps {
+		t.Run(step.label, func(t *testing.T) {
+			_, err := emailvalidator.BuildResult(step.input, step.preset)
+			if step.err == nil && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			} else if step.err != nil && !errors.Is(err, step.err) {
+				t.Errorf("expected %v, saw %v", step.err, err)
+			}
+		})
+	}
+}
+
+func TestSubAddress(t *testing.T) {
+	res, err := emailvalidator.BuildResult("user.name+tag+sorting@example.com", func(opt *emailvalidator.ParseOptions) {
+		opt.SubAddressSeparators = "+"
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Mailbox != "user.name" || res.SubAddress != "tag+sorting" {
+		t.Errorf("unexpected split: mailbox=%q sub=%q", res.Mailbox, res.SubAddress)
+	}
+	if res.Local != "user.name+tag+sorting" {
+		t.Errorf("expected Local to be unmodified, saw %q", res.Local)
+	}
+}
+
+func TestSubAddress_Degenerate(t *testing.T) {
+	separators := func(severity emailvalidator.Severity) emailvalidator.OptFunc {
+		return func(opt *emailvalidator.ParseOptions) {
+			opt.SubAddressSeparators = "+"
+			opt.MaxConsecutiveSeparators = 1
+			opt.SubAddressSeverity = severity
+		}
+	}
+
+	steps := []struct {
+		label    string
+		input    string
+		severity emailvalidator.Severity
+		err      error
+		warnings int
+	}{
+		{label: "ok", input: "user+tag@example.com"},
+		{label: "empty-tag", input: "user+@example.com", err: emailvalidator.ErrEmptySubAddress},
+		{label: "consecutive", input: "user++tag@example.com", err: emailvalidator.ErrConsecutiveSeparators},
+		{label: "only-separators", input: "user++++@example.com", err: emailvalidator.ErrEmptySubAddress},
+		{label: "warning", input: "user++++tag@example.com", severity: emailvalidator.SeverityWarning, warnings: 1},
+		{label: "ignore", input: "user+@example.com", severity: emailvalidator.SeverityIgnore},
+	}
+
+	for _, step := range steps {
+		t.Run(step.label, func(t *testing.T) {
+			res, err := emailvalidator.BuildResult(step.input, separators(step.severity))
+			if step.err == nil && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			} else if step.err != nil && !errors.Is(err, step.err) {
+				t.Errorf("expected %v, saw %v", step.err, err)
+			}
+			if len(res.Warnings) != step.warnings {
+				t.Errorf("expected %d warnings, saw %v", step.warnings, res.Warnings)
+			}
+		})
+	}
+}
+
+func TestPresetStrictSMTP(t *testing.T) {
+	steps := []struct {
+		label string
+		input string
+		err   error
+	}{
+		{label: "dot-atom", input: "john.doe@example.com"},
+		{label: "quoted-string", input: `"john doe"@example.com`},
+		{label: "quoted-escapes", input: `"john\"doe"@example.com`},
+		{label: "domain-comment", input: "john@example.com (work)"},
+		{label: "leading-comment", input: "(work)john@example.com", err: emailvalidator.ErrUnexpectedCharacter},
+		{label: "trailing-comment", input: "john(work)@example.com", err: emailvalidator.ErrUnexpectedCharacter},
+		{label: "empty-atom", input: "john.@example.com", err: emailvalidator.ErrUnexpectedCharacter},
+		{label: "mixed-quoting", input: `"john".doe@example.com`, err: emailvalidator.ErrInvalidUnquotedSequence},
+		{label: "quoted-segments", input: `"john"."doe"@example.com`, err: emailvalidator.ErrInvalidUnquotedSequence},
+	}
+
+	for _, step := range steps {
+		t.Run(step.label, func(t *testing.T) {
+			_, err := emailvalidator.BuildResult(step.input, emailvalidator.PresetStrictSMTP)
+			if step.err == nil && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			} else if step.err != nil && !errors.Is(err, step.err) {
+				t.Errorf("expected %v, saw %v", step.err, err)
+			}
+
+			// none of these are rejected by default
+			if _, err = emailvalidator.BuildResult(step.input); err != nil {
+				t.Errorf("unexpected error without preset: %v", err)
+			}
+		})
+	}
+}
+
+func TestPresetParanoid(t *testing.T) {
+	steps := []struct {
+		label string
+		input string
+		err   error
+	}{
+		{label: "ok", input: "john.doe+news@example.com"},
+		{label: "comment", input: "john@example.com (work)", err: emailvalidator.ErrUnsafeAddress},
+		{label: "quoted", input: `"john doe"@example.com`, err: emailvalidator.ErrUnsafeAddress},
+		{label: "literal", input: "john@[192.168.0.1]", err: emailvalidator.ErrUnsafeAddress},
+		{label: "source-route", input: "@relay.example.com:john@example.com", err: emailvalidator.ErrUnsafeAddress},
+		{label: "control", input: "john\x00@example.com", err: emailvalidator.ErrUnsafeAddress},
+		{label: "non-ascii", input: "jöhn@example.com", err: emailvalidator.ErrUnsafeAddress},
+		{label: "long", input: strings.Repeat("a", 64) + "@" + strings.Repeat("b", 190) + ".com", err: emailvalidator.ErrUnsafeAddress},
+		{label: "syntax", input: "jo..hn@example..com", err: emailvalidator.ErrInvalidUnquotedSequence},
+	}
+
+	for _, step := range steps {
+		t.Run(step.label, func(t *testing.T) {
+			_, err := emailvalidator.BuildResult(step.input, emailvalidator.PresetParanoid)
+			if step.err == nil && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			} else if step.err != nil && !errors.Is(err, step.err) {
+				t.Errorf("expected %v, saw %v", step.err, err)
+			}
+			if perrs := emailvalidator.ErrorsOf(err); len(perrs) > 1 {
+				t.Errorf("expected a single error, saw %d", len(perrs))
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package emailvalidator
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// Pseudonymize returns a stable, hex-encoded HMAC-SHA256 pseudonym of email's normalized form, keyed by key.
+// Equivalent addresses, e.g. those differing only in domain case or in unnecessary quoting, produce the same
+// pseudonym, allowing analytics pipelines to join on addresses without storing them.  Addresses that fail to parse are keyed by their
+// trimmed input, as with Deduplicator.
+//
+// Pseudonyms are only as secret as key: anyone holding it may confirm a guessed address, so it must be protected like
+// a password and rotated if exposed.
+func Pseudonymize(email string, key []byte) string {
+	canonical := strings.TrimSpace(email)
+	if res, err := BuildResult(email); err == nil {
+		canonical = NormalizeKey(res)
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(canonical))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,37 @@
+package emailvalidator_test
+
+import (
+	"testing"
+
+	emailvalidator "github.com/dcarbone/go-email-validator"
+)
+
+func TestPseudonymize(t *testing.T) {
+	key := []byte("secret")
+	base := emailvalidator.Pseudonymize("jane@example.com", key)
+	if len(base) != 64 {
+		t.Fatalf("expected a 64 character pseudonym, saw %q", base)
+	}
+
+	steps := []struct {
+		label string
+		input string
+		key   []byte
+		same  bool
+	}{
+		{label: "identical", input: "jane@example.com", key: key, same: true},
+		{label: "domain-case", input: "jane@EXAMPLE.com", key: key, same: true},
+		{label: "quoted", input: `"jane"@example.com`, key: key, same: true},
+		{label: "local-case", input: "Jane@example.com", key: key},
+		{label: "other-key", input: "jane@example.com", key: []byte("other")},
+		{label: "other-address", input: "john@example.com", key: key},
+	}
+
+	for _, step := range steps {
+		t.Run(step.label, func(t *testing.T) {
+			if same := emailvalidator.Pseudonymize(step.input, step.key) == base; same != step.same {
+				t.Errorf("expected same=%t", step.same)
+			}
+		})
+	}
+}
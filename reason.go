@@ -0,0 +1,46 @@
+package emailvalidator
+
+// Reason is a stable, coarse-grained explanation of why an address was not valid, suitable for switching on within
+// business logic.  Reasons will never be renamed or reused.
+type Reason string
+
+const (
+	// ReasonSyntaxError is returned if the address is not syntactically valid
+	ReasonSyntaxError Reason = "syntax_error"
+
+	// ReasonUnsafe is returned if the address uses a construct commonly used for injection or spoofing
+	ReasonUnsafe Reason = "unsafe"
+
+	// ReasonRoleAccount is returned if the address was rejected for being a role account
+	ReasonRoleAccount Reason = "role_account"
+
+	// ReasonBlocked is returned if the address violated a Policy
+	ReasonBlocked Reason = "blocked"
+
+	// ReasonUnknown is returned if validation could not be completed, e.g. because a remote validator was unreachable
+	ReasonUnknown Reason = "unknown"
+)
+
+// reasons maps codes to the Reason they produce.  Codes absent from this map produce ReasonSyntaxError.
+var reasons = map[ErrorCode]Reason{
+	CodePossibleHeaderInjection: ReasonUnsafe,
+	CodeBidiControlCharacter:    ReasonUnsafe,
+	CodeUnsafeAddress:           ReasonUnsafe,
+	CodeRoleAccount:             ReasonRoleAccount,
+	CodePolicyViolation:         ReasonBlocked,
+	CodeRemoteValidator:         ReasonUnknown,
+	CodeUnknown:                 ReasonUnknown,
+}
+
+// ReasonOf returns the Reason for the error returned by BuildResult, or "" if err is nil.  If err contains several
+// errors, the Reason of the first is returned.
+func ReasonOf(err error) Reason {
+	errs := flattenErrors(err)
+	if len(errs) == 0 {
+		return ""
+	}
+	if reason, ok := reasons[CodeOf(errs[0])]; ok {
+		return reason
+	}
+	return ReasonSyntaxError
+}
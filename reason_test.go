@@ -0,0 +1,64 @@
+package emailvalidator_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	emailvalidator "github.com/dcarbone/go-email-validator"
+)
+
+func TestReasonOf(t *testing.T) {
+	tests := []struct {
+		email string
+		opts  []emailvalidator.OptFunc
+		want  emailvalidator.Reason
+	}{
+		{"user@example.com", nil, ""},
+		{"a@b@example.com", nil, emailvalidator.ReasonSyntaxError},
+		{"", nil, emailvalidator.ReasonSyntaxError},
+		{"user\r\n@example.com", nil, emailvalidator.ReasonUnsafe},
+		{"\"user\"@example.com", []emailvalidator.OptFunc{emailvalidator.PresetParanoid}, emailvalidator.ReasonUnsafe},
+		{
+			"postmaster@example.com",
+			[]emailvalidator.OptFunc{emailvalidator.WithRoleAccountSeverity(emailvalidator.SeverityError, "postmaster")},
+			emailvalidator.ReasonRoleAccount,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.email, func(t *testing.T) {
+			_, err := emailvalidator.BuildResult(tt.email, tt.opts...)
+			if got := emailvalidator.ReasonOf(err); got != tt.want {
+				t.Errorf("expected reason %q, saw %q (err: %v)", tt.want, got, err)
+			}
+		})
+	}
+}
+
+func TestReasonOf_Other(t *testing.T) {
+	policy := emailvalidator.Policy{emailvalidator.MinLocalLength(3)}
+	_, violations, _ := policy.Check("ab@example.com")
+	if got := emailvalidator.ReasonOf(violations[0]); got != emailvalidator.ReasonBlocked {
+		t.Errorf("expected reason %q, saw %q", emailvalidator.ReasonBlocked, got)
+	}
+
+	err := fmt.Errorf("%w: connection refused", emailvalidator.ErrRemoteValidator)
+	if got := emailvalidator.ReasonOf(err); got != emailvalidator.ReasonUnknown {
+		t.Errorf("expected reason %q, saw %q", emailvalidator.ReasonUnknown, got)
+	}
+	if got := emailvalidator.ReasonOf(errors.New("boom")); got != emailvalidator.ReasonUnknown {
+		t.Errorf("expected reason %q, saw %q", emailvalidator.ReasonUnknown, got)
+	}
+}
+
+func TestNewReport_Reason(t *testing.T) {
+	res, err := emailvalidator.BuildResult("a@b@example.com")
+	if rep := emailvalidator.NewReport(res, err, time.Millisecond); rep.Reason != emailvalidator.ReasonSyntaxError {
+		t.Errorf("expected reason %q, saw %q", emailvalidator.ReasonSyntaxError, rep.Reason)
+	}
+	res, err = emailvalidator.BuildResult("user@example.com")
+	if rep := emailvalidator.NewReport(res, err, time.Millisecond); rep.Reason != "" {
+		t.Errorf("expected no reason, saw %q", rep.Reason)
+	}
+}
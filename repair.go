@@ -0,0 +1,247 @@
+package emailvalidator
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+type RepairOptions struct {
+	// ParseOptions are used when parsing the address, both to decide which repairs are needed and to validate the result
+	ParseOptions []OptFunc
+
+	// Suggester proposes domain corrections.  Defaults to a Suggester with default options.
+	Suggester *Suggester
+}
+
+type RepairOptFunc func(*RepairOptions)
+
+func WithRepairParseOptions(opts ...OptFunc) RepairOptFunc {
+	return func(opt *RepairOptions) {
+		opt.ParseOptions = append(opt.ParseOptions, opts...)
+	}
+}
+
+func WithRepairSuggester(s *Suggester) RepairOptFunc {
+	return func(opt *RepairOptions) {
+		opt.Suggester = s
+	}
+}
+
+// FixKind categorizes a Fix
+type FixKind string
+
+const (
+	// FixTrim removes leading and trailing whitespace
+	FixTrim FixKind = "trim"
+
+	// FixPercentDecode decodes percent-encoded input, e.g. "user%40example.com"
+	FixPercentDecode FixKind = "percent_decode"
+
+	// FixHTMLDecode decodes HTML character references, e.g. "user&#64;example.com"
+	FixHTMLDecode FixKind = "html_decode"
+
+	// FixPunctuation replaces a Unicode punctuation character commonly introduced by word processors or input methods
+	// with its ASCII equivalent, e.g. a curly quote or full-width "＠"
+	FixPunctuation FixKind = "punctuation"
+
+	// FixInvisible removes a zero-width or otherwise invisible character
+	FixInvisible FixKind = "invisible"
+
+	// FixDoubleAt collapses a run of consecutive "@" characters into one
+	FixDoubleAt FixKind = "double_at"
+
+	// FixDomain replaces a likely-mistyped domain with the closest suggestion, e.g. "gmial.com" with "gmail.com"
+	FixDomain FixKind = "domain"
+)
+
+// Fix is a single correction made to an address before it was parsed
+type Fix struct {
+	Kind FixKind `json:"kind"`
+
+	// Position is the byte offset of the corrected text within the input to the repair pass that made the fix, or -1
+	// if the fix applied to the entire input
+	Position int `json:"position"`
+
+	// From is the text that was replaced, and To its replacement
+	From string `json:"from"`
+	To   string `json:"to"`
+
+	// Confidence is the likelihood that the fix is correct, from 0 to 1.  See ConfidenceLevelOf.
+	Confidence float64 `json:"confidence"`
+}
+
+// Level returns the ConfidenceLevel of f
+func (f Fix) Level() ConfidenceLevel {
+	return ConfidenceLevelOf(f.Confidence)
+}
+
+// punctuationRepairs maps punctuation commonly pasted into addresses to its ASCII equivalent.  Full-width forms are
+// handled separately.
+var punctuationRepairs = map[rune]string{
+	'\u2018': "'",  // left single quotation mark
+	'\u2019': "'",  // right single quotation mark
+	'\u201a': "'",  // single low-9 quotation mark
+	'\u201b': "'",  // single high-reversed-9 quotation mark
+	'\u201c': "\"", // left double quotation mark
+	'\u201d': "\"", // right double quotation mark
+	'\u201e': "\"", // double low-9 quotation mark
+	'\u201f': "\"", // double high-reversed-9 quotation mark
+	'\u2010': "-",  // hyphen
+	'\u2011': "-",  // non-breaking hyphen
+	'\u2012': "-",  // figure dash
+	'\u2013': "-",  // en dash
+	'\u2014': "-",  // em dash
+	'\u2212': "-",  // minus sign
+	'\u3000': " ",  // ideographic space
+	'\u3002': ".",  // ideographic full stop
+	'\uff61': ".",  // halfwidth ideographic full stop
+	'\ufe52': ".",  // small full stop
+	'\ufe6b': "@",  // small commercial at
+}
+
+// repairPunctuation returns email with Unicode punctuation replaced by its ASCII equivalent, and a Fix for each
+// replacement made
+func repairPunctuation(email string) (string, []Fix) {
+	var (
+		b     strings.Builder
+		fixes []Fix
+	)
+	for i, r := range email {
+		if r < utf8.RuneSelf {
+			continue
+		}
+		repl, ok := punctuationRepairs[r]
+		if !ok && r >= '\uff01' && r <= '\uff5e' {
+			// full-width forms occupy a contiguous block mirroring ASCII
+			repl, ok = string(r-0xfee0), true
+		}
+		if ok {
+			fixes = append(fixes, newFix(FixPunctuation, i, string(r), repl))
+		}
+	}
+	if len(fixes) == 0 {
+		return email, nil
+	}
+
+	b.Grow(len(email))
+	last := 0
+	for _, fix := range fixes {
+		b.WriteString(email[last:fix.Position])
+		b.WriteString(fix.To)
+		last = fix.Position + len(fix.From)
+	}
+	b.WriteString(email[last:])
+	return b.String(), fixes
+}
+
+// Repair applies every available correction to email, for intake pipelines that would rather fix an address than
+// reject it, and returns the corrected address, each Fix applied in order, any domain suggestions that were not
+// applied, and the error returned by BuildResult for the corrected address.  Repairs are attempted in this order:
+//
+//   - leading and trailing whitespace is trimmed
+//   - percent-encoding and HTML character references are decoded
+//   - Unicode punctuation is replaced with its ASCII equivalent
+//   - invisible characters are removed
+//   - runs of consecutive "@" are collapsed, if the address does not otherwise parse
+//   - a likely-mistyped domain is replaced with the closest suggestion, if it is of ConfidenceHigh
+//
+// Domains that are candidates or known domains of the Suggester are never replaced.  Suggestions that are not
+// applied, e.g. those of lower confidence, are returned for the caller to confirm instead.
+//
+// The corrected address is returned even if it remains invalid, so that callers may inspect what was attempted.
+func Repair(email string, opts ...RepairOptFunc) (fixed string, applied []Fix, suggested []Suggestion, err error) {
+	var repairOpts RepairOptions
+	for _, fn := range opts {
+		fn(&repairOpts)
+	}
+	if repairOpts.Suggester == nil {
+		repairOpts.Suggester = NewSuggester()
+	}
+
+	fixed = email
+	replace := func(kind FixKind, to string) {
+		if to != fixed {
+			applied = append(applied, newFix(kind, -1, fixed, to))
+			fixed = to
+		}
+	}
+
+	replace(FixTrim, strings.TrimSpace(fixed))
+	if decoded, pe := decodePercent(fixed); pe != nil {
+		replace(FixPercentDecode, decoded)
+	}
+	if decoded, pe := decodeHTMLEntities(fixed); pe != nil {
+		replace(FixHTMLDecode, decoded)
+	}
+
+	var fixes []Fix
+	fixed, fixes = repairPunctuation(fixed)
+	applied = append(applied, fixes...)
+
+	fixed, fixes = repairInvisible(fixed)
+	applied = append(applied, fixes...)
+
+	if _, err = BuildResult(fixed, repairOpts.ParseOptions...); err != nil && strings.Contains(fixed, "@@") {
+		fixed, fixes = collapseAts(fixed)
+		applied = append(applied, fixes...)
+	}
+
+	res, err := BuildResult(fixed, repairOpts.ParseOptions...)
+	if res.Domain == "" || res.LiteralDomain || repairOpts.Suggester.isKnown(res.Domain) {
+		return fixed, applied, nil, err
+	}
+
+	suggested = repairOpts.Suggester.SuggestDomain(res.Domain)
+	at := res.DomainPosition
+	if len(suggested) == 0 || suggested[0].Level() != ConfidenceHigh || !strings.HasPrefix(fixed[at:], res.Domain) {
+		// comments within the domain leave nowhere to splice a replacement
+		local := res.Stripped[:strings.LastIndexByte(res.Stripped, '@')]
+		for i := range suggested {
+			suggested[i].Address = local + "@" + suggested[i].Domain
+		}
+		return fixed, applied, suggested, err
+	}
+
+	fix := newFix(FixDomain, at, res.Domain, suggested[0].Domain)
+	fix.Confidence = suggested[0].Confidence
+	applied = append(applied, fix)
+	fixed = fixed[:at] + suggested[0].Domain + fixed[at+len(res.Domain):]
+	_, err = BuildResult(fixed, repairOpts.ParseOptions...)
+	return fixed, applied, nil, err
+}
+
+// repairInvisible returns email with invisible characters removed, and a Fix for each removal
+func repairInvisible(email string) (string, []Fix) {
+	var fixes []Fix
+	for i, r := range email {
+		if isInvisible(r) {
+			fixes = append(fixes, newFix(FixInvisible, i, string(r), ""))
+		}
+	}
+	if len(fixes) == 0 {
+		return email, nil
+	}
+	return stripInvisible(email), fixes
+}
+
+// collapseAts returns email with each run of consecutive "@" replaced by a single "@", and a Fix for each run
+func collapseAts(email string) (string, []Fix) {
+	var (
+		b     strings.Builder
+		fixes []Fix
+	)
+	for i := 0; i < len(email); i++ {
+		j := i
+		for j < len(email) && email[j] == '@' {
+			j++
+		}
+		if j-i > 1 {
+			fixes = append(fixes, newFix(FixDoubleAt, i, email[i:j], "@"))
+			b.WriteByte('@')
+			i = j - 1
+			continue
+		}
+		b.WriteByte(email[i])
+	}
+	return b.String(), fixes
+}
@@ -0,0 +1,116 @@
+package emailvalidator_test
+
+import (
+	"reflect"
+	"testing"
+
+	emailvalidator "github.com/dcarbone/go-email-validator"
+)
+
+func TestRepair(t *testing.T) {
+	tests := []struct {
+		input string
+		fixed string
+		kinds []emailvalidator.FixKind
+		valid bool
+	}{
+		{"user@example.com", "user@example.com", nil, true},
+		{"  user@example.com\n", "user@example.com", []emailvalidator.FixKind{emailvalidator.FixTrim}, true},
+		{"user%40example.com", "user@example.com", []emailvalidator.FixKind{emailvalidator.FixPercentDecode}, true},
+		{"user&#64;example.com", "user@example.com", []emailvalidator.FixKind{emailvalidator.FixHTMLDecode}, true},
+		{"user\uff20example\u3002com", "user@example.com", []emailvalidator.FixKind{emailvalidator.FixPunctuation, emailvalidator.FixPunctuation}, true},
+		{"us\u200ber@example.com", "user@example.com", []emailvalidator.FixKind{emailvalidator.FixInvisible}, true},
+		{"user@@example.com", "user@example.com", []emailvalidator.FixKind{emailvalidator.FixDoubleAt}, true},
+		{"jane@gmaul.com", "jane@gmail.com", []emailvalidator.FixKind{emailvalidator.FixDomain}, true},
+		{"jane@gmial.com", "jane@gmial.com", nil, true},
+		{"jane@gmaul.com (gmaul.com)", "jane@gmail.com (gmaul.com)", []emailvalidator.FixKind{emailvalidator.FixDomain}, false},
+		{"jane@ymail.com", "jane@ymail.com", nil, true},
+		{"jane@aim.com", "jane@aim.com", nil, true},
+		{"jane@gmx.net", "jane@gmx.net", nil, true},
+		{"jane@live.ca", "jane@live.ca", nil, true},
+		{"jane@yahoo.ca", "jane@yahoo.ca", nil, true},
+		{
+			" jane%40gmaul.com ",
+			"jane@gmail.com",
+			[]emailvalidator.FixKind{emailvalidator.FixTrim, emailvalidator.FixPercentDecode, emailvalidator.FixDomain},
+			true,
+		},
+		{"not an address", "not an address", nil, false},
+	}
+	for _, tt := range tests {
+		fixed, applied, _, err := emailvalidator.Repair(tt.input)
+		if fixed != tt.fixed {
+			t.Errorf("%q: expected %q, saw %q", tt.input, tt.fixed, fixed)
+		}
+		var kinds []emailvalidator.FixKind
+		for _, fix := range applied {
+			kinds = append(kinds, fix.Kind)
+		}
+		if !reflect.DeepEqual(kinds, tt.kinds) {
+			t.Errorf("%q: expected fixes %v, saw %+v", tt.input, tt.kinds, applied)
+		}
+		if (err == nil) != tt.valid {
+			t.Errorf("%q: expected valid=%t, saw %v", tt.input, tt.valid, err)
+		}
+	}
+}
+
+func TestRepair_Fixes(t *testing.T) {
+	_, applied, _, err := emailvalidator.Repair("jane@@gmaul.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []emailvalidator.Fix{
+		{Kind: emailvalidator.FixDoubleAt, Position: 4, From: "@@", To: "@", Confidence: 0.9},
+		{Kind: emailvalidator.FixDomain, Position: 5, From: "gmaul.com", To: "gmail.com", Confidence: 1 - 0.5/9},
+	}
+	if !reflect.DeepEqual(applied, want) {
+		t.Errorf("expected %+v, saw %+v", want, applied)
+	}
+	if applied[0].Level() != emailvalidator.ConfidenceHigh || applied[1].Level() != emailvalidator.ConfidenceHigh {
+		t.Errorf("unexpected confidence levels %s, %s", applied[0].Level(), applied[1].Level())
+	}
+
+	// the domain is replaced where it was parsed, not where its text last appears
+	suggester := emailvalidator.NewSuggester(emailvalidator.WithDomains("example"))
+	fixed, _, _, err := emailvalidator.Repair("jane@exanple (exanple)", emailvalidator.WithRepairSuggester(suggester))
+	if fixed != "jane@example (exanple)" || err != nil {
+		t.Errorf("expected the domain to be replaced, saw %q, %v", fixed, err)
+	}
+
+	suggester = emailvalidator.NewSuggester(emailvalidator.WithDomains("example.com"))
+	fixed, applied, _, _ = emailvalidator.Repair("jane@gmaul.com", emailvalidator.WithRepairSuggester(suggester))
+	if fixed != "jane@gmaul.com" || len(applied) != 0 {
+		t.Errorf("expected no domain fix, saw %q %+v", fixed, applied)
+	}
+}
+
+func TestRepair_Suggestions(t *testing.T) {
+	tests := []struct {
+		input     string
+		suggested []string
+	}{
+		// a transposition is less certain than an adjacent key, so is left for the caller to confirm
+		{"jane@gmial.com", []string{"jane@gmail.com"}},
+		{"(c)jane@gmial.com", []string{"jane@gmail.com"}},
+		{"jane@gmaul.com", nil},
+		{"jane@gmail.com", nil},
+		{"jane@ymail.com", nil},
+	}
+	for _, tt := range tests {
+		fixed, _, suggested, err := emailvalidator.Repair(tt.input)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tt.input, err)
+		}
+		if len(tt.suggested) > 0 && fixed != tt.input {
+			t.Errorf("%q: expected no domain fix, saw %q", tt.input, fixed)
+		}
+		var addresses []string
+		for _, sg := range suggested {
+			addresses = append(addresses, sg.Address)
+		}
+		if !reflect.DeepEqual(addresses, tt.suggested) {
+			t.Errorf("%q: expected suggestions %v, saw %+v", tt.input, tt.suggested, suggested)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package emailvalidator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// ReportSchemaVersion is the version of the Report JSON schema.  It is incremented whenever a field is removed or its
+// meaning changes; new fields may be added without a version change.
+const ReportSchemaVersion = 1
+
+// ReportTimings contains the time spent in each stage of validation
+type ReportTimings struct {
+	// ParseMicros is the time spent parsing the address, in microseconds
+	ParseMicros int64 `json:"parse_us"`
+}
+
+// Report is the stable, machine-readable form of a single address's validation outcome, suitable for consumption by
+// downstream ETL.  It is written by Handler when the "format" parameter is "report", and by WriteReports.
+type Report struct {
+	SchemaVersion int           `json:"schema_version"`
+	Input         string        `json:"input"`
+	Verdict       Verdict       `json:"verdict"`
+	Reason        Reason        `json:"reason,omitempty"`
+	Normalized    string        `json:"normalized"`
+	Local         string        `json:"local"`
+	Domain        string        `json:"domain"`
+	Flags         Flags         `json:"flags"`
+	Errors        ErrorList     `json:"errors"`
+	Warnings      ErrorList     `json:"warnings"`
+	Fixes         []Fix         `json:"fixes,omitempty"`
+	Timings       ReportTimings `json:"timings"`
+}
+
+// NewReport builds a Report from the Result and error returned by BuildResult, and the time taken to produce them
+func NewReport(res Result, err error, elapsed time.Duration) Report {
+	rep := Report{
+		SchemaVersion: ReportSchemaVersion,
+		Input:         res.Input,
+		Verdict:       VerdictOf(err),
+		Reason:        ReasonOf(err),
+		Normalized:    res.Stripped,
+		Local:         res.Local,
+		Domain:        res.Domain,
+		Flags:         res.Flags,
+		Errors:        ErrorListOf(err),
+		Warnings:      make(ErrorList, len(res.Warnings)),
+		Fixes:         res.Fixes,
+		Timings:       ReportTimings{ParseMicros: elapsed.Microseconds()},
+	}
+	if rep.Errors == nil {
+		rep.Errors = ErrorList{}
+	}
+	for i, pe := range res.Warnings {
+		rep.Warnings[i] = pe
+	}
+	return rep
+}
+
+// WriteReports validates each address read from r, writing one JSON-encoded Report per line to w.  Input is read as
+// configured by WithInputFormat, by default detecting whether it is plain lines, CSV, TSV, or JSONL.  A header row
+// within CSV or TSV input is skipped.  Lists of plain addresses that may contain commas should set FormatLines
+// explicitly.  Lines longer than MaxLineLength are reported as invalid, and do not end the run.
+//
+// If a Sink is configured, each Result is also delivered to it, and it is flushed once the run ends, whether or not
+// every address was processed.
+// If a Journal is configured, addresses it marks complete are skipped, and every processed address is marked complete
+// once its report is written and any Sink is flushed.
+//
+// If ctx is cancelled mid-run, the context's error is returned alongside a Summary of the addresses processed before
+// cancellation.
+func WriteReports(ctx context.Context, r io.Reader, w io.Writer, opts ...BulkOptFunc) (summary Summary, err error) {
+	var (
+		bulkOpts = buildBulkOptions(opts)
+		enc      = json.NewEncoder(w)
+	)
+	summary.canonicalize = bulkOpts.Canonicalize
+
+	// partial results are delivered however the run ends
+	defer func() { err = flushSink(bulkOpts.Sink, err) }()
+
+	ar, err := newAddressReader(r, bulkOpts)
+	if err != nil {
+		return summary, err
+	}
+
+	for offset := 0; ; offset++ {
+		if err = ctx.Err(); err != nil {
+			return summary, err
+		}
+
+		fields, address, readErr := ar.next()
+		if errors.Is(readErr, io.EOF) {
+			break
+		} else if readErr != nil && !errors.Is(readErr, ErrCSVColumnMissing) && !errors.Is(readErr, ErrLineTooLong) {
+			return summary, readErr
+		}
+
+		var key string
+		if bulkOpts.Journal != nil {
+			key = journalKey(offset, fields...)
+			if bulkOpts.Journal.Completed(key) {
+				summary.Resumed++
+				continue
+			}
+		}
+
+		start := time.Now()
+		res, err := Result{Err: readErr}, readErr
+		if readErr == nil {
+			res, err = BuildResult(address, bulkOpts.ParseOptions...)
+		}
+		elapsed := time.Since(start)
+
+		summary.add(res, err)
+
+		if err = enc.Encode(NewReport(res, err, elapsed)); err != nil {
+			return summary, fmt.Errorf("error writing report: %w", err)
+		}
+
+		if bulkOpts.Sink != nil {
+			if err = bulkOpts.Sink.OnResult(res); err != nil {
+				return summary, err
+			}
+		}
+
+		if bulkOpts.Journal != nil {
+			if err = completeRecord(bulkOpts.Sink, bulkOpts.Journal, key); err != nil {
+				return summary, err
+			}
+		}
+
+		if bulkOpts.Progress != nil {
+			bulkOpts.Progress(summary.Total, -1)
+		}
+	}
+	return summary, nil
+}
@@ -0,0 +1,83 @@
+package emailvalidator_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	emailvalidator "github.com/dcarbone/go-email-validator"
+)
+
+func TestWriteReports(t *testing.T) {
+	var buf bytes.Buffer
+	summary, err := emailvalidator.WriteReports(
+		context.Background(),
+		strings.NewReader("\"john.doe\"@example.com\na@b@c@example.com\n"),
+		&buf,
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if summary.Total != 2 {
+		t.Errorf("expected 2 addresses, saw %d", summary.Total)
+	}
+
+	dec := json.NewDecoder(&buf)
+
+	var valid emailvalidator.Report
+	if err := dec.Decode(&valid); err != nil {
+		t.Fatalf("error decoding report: %v", err)
+	}
+	if valid.SchemaVersion != emailvalidator.ReportSchemaVersion {
+		t.Errorf("expected schema version %d, saw %d", emailvalidator.ReportSchemaVersion, valid.SchemaVersion)
+	}
+	if valid.Verdict != emailvalidator.VerdictValid || valid.Normalized != "john.doe@example.com" || len(valid.Errors) != 0 {
+		t.Errorf("unexpected report: %+v", valid)
+	}
+
+	var invalid emailvalidator.Report
+	if err := dec.Decode(&invalid); err != nil {
+		t.Fatalf("error decoding report: %v", err)
+	}
+	if invalid.Verdict != emailvalidator.VerdictInvalid || !errors.Is(invalid.Errors, emailvalidator.ErrUnexpectedCharacter) {
+		t.Errorf("unexpected report: %+v", invalid)
+	}
+}
+
+func TestWriteReports_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var buf bytes.Buffer
+	_, err := emailvalidator.WriteReports(ctx, strings.NewReader("a@example.com\n"), &buf)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, saw %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected no output, saw %q", buf.String())
+	}
+}
+
+func TestHandler_Report(t *testing.T) {
+	rec := httptest.NewRecorder()
+	emailvalidator.NewHandler(emailvalidator.NewValidator()).
+		ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/?format=report&email=user@example.com", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, saw %d", rec.Code)
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(rec.Body.Bytes(), &fields); err != nil {
+		t.Fatalf("error decoding report: %v", err)
+	}
+	for _, name := range []string{"schema_version", "input", "verdict", "normalized", "local", "domain", "flags", "errors", "warnings", "timings"} {
+		if _, ok := fields[name]; !ok {
+			t.Errorf("expected report field %q", name)
+		}
+	}
+}
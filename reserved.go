@@ -0,0 +1,89 @@
+package emailvalidator
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/fs"
+	"strings"
+)
+
+// DefaultReservedWords contains words commonly used within locals to impersonate an organization's staff, e.g.
+// "paypal-security" or "support.team"
+var DefaultReservedWords = []string{
+	"admin",
+	"billing",
+	"moderator",
+	"official",
+	"security",
+	"staff",
+	"support",
+	"sysadmin",
+	"verification",
+}
+
+// WithReservedWords sets the words screened for by ParseOptions.ReservedWords, replacing any previously configured
+// words, e.g. WithReservedWords(DefaultReservedWords...).  Lists may be loaded with LoadWordList.
+func WithReservedWords(words ...string) OptFunc {
+	return func(opt *ParseOptions) {
+		opt.ReservedWords = append([]string(nil), words...)
+	}
+}
+
+// LoadWordList reads words from r, one per line.  Blank lines and lines beginning with "#" are skipped.
+func LoadWordList(r io.Reader) ([]string, error) {
+	var (
+		words   []string
+		scanner = bufio.NewScanner(r)
+	)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		words = append(words, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading word list: %w", err)
+	}
+	return words, nil
+}
+
+// LoadWordListFS calls LoadWordList with the contents of the file name within fsys, e.g. an embed.FS bundled into the
+// binary
+func LoadWordListFS(fsys fs.FS, name string) ([]string, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("error opening word list: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+	return LoadWordList(f)
+}
+
+// normalizeReserved lower-cases s and removes the separators commonly used to evade word screens, so that e.g.
+// "Sec.Ur-ity" matches "security"
+func normalizeReserved(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '.', '-', '_':
+			return -1
+		}
+		return r
+	}, strings.ToLower(s))
+}
+
+// checkReserved sets res.ReservedWord to the first of the configured ParseOptions.ReservedWords seen within the
+// mailbox, if any
+func checkReserved(res *Result, opts *ParseOptions) {
+	if len(opts.ReservedWords) == 0 || res.Mailbox == "" {
+		return
+	}
+
+	mailbox := normalizeReserved(unquoteLocal(res.Mailbox))
+	for _, word := range opts.ReservedWords {
+		if w := normalizeReserved(word); w != "" && strings.Contains(mailbox, w) {
+			res.ReservedWord = word
+			return
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package emailvalidator_test
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	emailvalidator "github.com/dcarbone/go-email-validator"
+)
+
+func TestReservedWords(t *testing.T) {
+	words, err := emailvalidator.LoadWordList(strings.NewReader("# impersonation\nsecurity\n\nBilling\nno-reply\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	opts := []emailvalidator.OptFunc{
+		emailvalidator.WithReservedWords(words...),
+		func(opt *emailvalidator.ParseOptions) { opt.SubAddressSeparators = "+" },
+	}
+
+	steps := []struct {
+		input string
+		word  string
+	}{
+		{input: "jane@example.com"},
+		{input: "security@example.com", word: "security"},
+		{input: "PayPal-Security@example.com", word: "security"},
+		{input: "sec.ur_ity@example.com", word: "security"},
+		{input: `"billing team"@example.com`, word: "Billing"},
+		{input: "noreply@example.com", word: "no-reply"},
+		{input: "jane+security@example.com"},
+	}
+
+	for _, step := range steps {
+		t.Run(step.input, func(t *testing.T) {
+			res, err := emailvalidator.BuildResult(step.input, opts...)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if res.ReservedWord != step.word {
+				t.Errorf("expected %q, saw %q", step.word, res.ReservedWord)
+			}
+			if res.Flags.Has(emailvalidator.FlagReservedWord) != (step.word != "") {
+				t.Errorf("unexpected flags %v", res.Flags)
+			}
+		})
+	}
+}
+
+func TestLoadWordListFS(t *testing.T) {
+	fsys := fstest.MapFS{"words.txt": {Data: []byte("security\n# comment\nbilling\n")}}
+
+	words, err := emailvalidator.LoadWordListFS(fsys, "words.txt")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(words) != 2 || words[0] != "security" || words[1] != "billing" {
+		t.Errorf("unexpected words %v", words)
+	}
+	if _, err = emailvalidator.LoadWordListFS(fsys, "missing.txt"); err == nil {
+		t.Error("expected error loading missing file")
+	}
+}
@@ -0,0 +1,82 @@
+package emailvalidator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultRoleAccounts contains mailbox names commonly used by teams or functions rather than individuals
+var DefaultRoleAccounts = []string{
+	"abuse",
+	"admin",
+	"billing",
+	"contact",
+	"help",
+	"hostmaster",
+	"info",
+	"marketing",
+	"no-reply",
+	"noreply",
+	"postmaster",
+	"sales",
+	"security",
+	"support",
+	"webmaster",
+}
+
+// WithRoleAccounts sets the Severity with which each of the provided role mailbox names is reported, replacing any
+// previously configured roles.
+func WithRoleAccounts(roles map[string]Severity) OptFunc {
+	return func(opt *ParseOptions) {
+		opt.RoleAccounts = make(map[string]Severity, len(roles))
+		for role, severity := range roles {
+			opt.RoleAccounts[strings.ToLower(role)] = severity
+		}
+	}
+}
+
+// WithRoleAccountSeverity sets the Severity with which each of the provided role mailbox names is reported, in
+// addition to any previously configured roles, e.g.
+//
+//	WithRoleAccountSeverity(SeverityWarning, DefaultRoleAccounts...)
+//	WithRoleAccountSeverity(SeverityError, "abuse")
+//
+// The existing roles are copied rather than modified, as they may be shared with a Validator's base options.
+func WithRoleAccountSeverity(severity Severity, roles ...string) OptFunc {
+	return func(opt *ParseOptions) {
+		existing := opt.RoleAccounts
+		opt.RoleAccounts = make(map[string]Severity, len(existing)+len(roles))
+		for role, sev := range existing {
+			opt.RoleAccounts[role] = sev
+		}
+		for _, role := range roles {
+			opt.RoleAccounts[strings.ToLower(role)] = severity
+		}
+	}
+}
+
+// checkRole sets res.RoleAccount if the mailbox is a configured role, returning an error or adding a warning per the
+// role's Severity
+func checkRole(res *Result, opts *ParseOptions) []error {
+	if len(opts.RoleAccounts) == 0 {
+		return nil
+	}
+
+	mailbox := strings.ToLower(unquoteLocal(res.Mailbox))
+	severity, ok := opts.RoleAccounts[mailbox]
+	if !ok {
+		return nil
+	}
+
+	res.RoleAccount = true
+
+	err := newParseError(fmt.Errorf("%w: %q", ErrRoleAccount, mailbox), -1, "", SegmentLocal)
+	switch severity {
+	case SeverityIgnore:
+	case SeverityWarning:
+		res.Warnings = append(res.Warnings, err)
+	default:
+		return []error{err}
+	}
+	return nil
+}
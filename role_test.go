@@ -0,0 +1,66 @@
+package emailvalidator_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	emailvalidator "github.com/dcarbone/go-email-validator"
+)
+
+func TestRoleAccounts(t *testing.T) {
+	opts := []emailvalidator.OptFunc{
+		emailvalidator.WithRoleAccountSeverity(emailvalidator.SeverityWarning, emailvalidator.DefaultRoleAccounts...),
+		emailvalidator.WithRoleAccountSeverity(emailvalidator.SeverityIgnore, "postmaster"),
+		emailvalidator.WithRoleAccountSeverity(emailvalidator.SeverityError, "Abuse"),
+		func(opt *emailvalidator.ParseOptions) { opt.SubAddressSeparators = "+" },
+	}
+
+	steps := []struct {
+		label    string
+		input    string
+		role     bool
+		warnings int
+		err      error
+	}{
+		{label: "person", input: "jane@example.com"},
+		{label: "ignored", input: "postmaster@example.com", role: true},
+		{label: "warning", input: "Sales@example.com", role: true, warnings: 1},
+		{label: "warning-sub-address", input: "sales+leads@example.com", role: true, warnings: 1},
+		{label: "warning-quoted", input: `"sales"@example.com`, role: true, warnings: 1},
+		{label: "error", input: "abuse@example.com", role: true, err: emailvalidator.ErrRoleAccount},
+	}
+
+	for _, step := range steps {
+		t.Run(step.label, func(t *testing.T) {
+			res, err := emailvalidator.BuildResult(step.input, opts...)
+			if step.err == nil && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			} else if step.err != nil && !errors.Is(err, step.err) {
+				t.Errorf("expected %v, saw %v", step.err, err)
+			}
+			if res.RoleAccount != step.role {
+				t.Errorf("expected RoleAccount %t, saw %t", step.role, res.RoleAccount)
+			}
+			if len(res.Warnings) != step.warnings {
+				t.Errorf("expected %d warnings, saw %v", step.warnings, res.Warnings)
+			} else if step.warnings > 0 && res.Warnings[0].Code != emailvalidator.CodeRoleAccount {
+				t.Errorf("expected %s warning, saw %s", emailvalidator.CodeRoleAccount, res.Warnings[0].Code)
+			}
+		})
+	}
+}
+
+func TestRoleAccounts_Config(t *testing.T) {
+	opts, err := emailvalidator.LoadConfig(strings.NewReader(`{"parse": {"role_accounts": {"postmaster": "ignore", "sales": "warning", "abuse": "error"}}}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err = emailvalidator.BuildResult("abuse@example.com", emailvalidator.WithOptions(opts)); !errors.Is(err, emailvalidator.ErrRoleAccount) {
+		t.Errorf("expected ErrRoleAccount, saw %v", err)
+	}
+	if res, err := emailvalidator.BuildResult("sales@example.com", emailvalidator.WithOptions(opts)); err != nil || len(res.Warnings) != 1 {
+		t.Errorf("expected a single warning, saw %v and %v", res.Warnings, err)
+	}
+}
@@ -0,0 +1,65 @@
+package emailvalidator
+
+import (
+	"context"
+)
+
+// Scorer assigns a risk score to a parsed address, where 0 is no risk and 1 is maximum risk.  Implement this to
+// replace the default flag weighting with a custom model, e.g. one hosted by a remote scoring service.
+type Scorer interface {
+	Score(ctx context.Context, res Result) (float64, error)
+}
+
+// ScorerFunc adapts a function to the Scorer interface
+type ScorerFunc func(ctx context.Context, res Result) (float64, error)
+
+func (fn ScorerFunc) Score(ctx context.Context, res Result) (float64, error) {
+	return fn(ctx, res)
+}
+
+// DefaultFlagWeights contains the per-flag weights used by the default WeightedScorer
+var DefaultFlagWeights = map[Flag]float64{
+	FlagRole:           0.2,
+	FlagSubAddress:     0.05,
+	FlagQuoted:         0.2,
+	FlagComment:        0.2,
+	FlagAddressLiteral: 0.4,
+	FlagQuotedAt:       0.3,
+	FlagDisposable:     0.6,
+	FlagFree:           0.05,
+	FlagSuppressed:     0.8,
+	FlagReservedWord:   0.3,
+	FlagPercentEncoded: 0.2,
+	FlagHTMLEncoded:    0.2,
+}
+
+// WeightedScorer is the default Scorer.  It sums the weight of every flag set on a result, plus WarningWeight for
+// each warning, capped at 1.  Invalid results, i.e. those with a non-nil Err, always score 1.
+type WeightedScorer struct {
+	// Weights contains the weight of each flag.  Defaults to DefaultFlagWeights.
+	Weights map[Flag]float64
+
+	// WarningWeight is added once per entry in Result.Warnings
+	WarningWeight float64
+}
+
+func (s WeightedScorer) Score(_ context.Context, res Result) (float64, error) {
+	if res.Err != nil {
+		return 1, nil
+	}
+
+	weights := s.Weights
+	if weights == nil {
+		weights = DefaultFlagWeights
+	}
+
+	// weights are summed in a fixed order, as floating point addition is not associative
+	score := float64(len(res.Warnings)) * s.WarningWeight
+	for _, fn := range flagNames {
+		if res.Flags.Has(fn.flag) {
+			score += weights[fn.flag]
+		}
+	}
+
+	return min(max(score, 0), 1), nil
+}
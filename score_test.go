@@ -0,0 +1,92 @@
+package emailvalidator_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http/httptest"
+	"testing"
+
+	emailvalidator "github.com/dcarbone/go-email-validator"
+)
+
+func TestWeightedScorer(t *testing.T) {
+	lists := emailvalidator.NewDomainLists()
+	lists.Set("disposable", []string{"mailinator.com"})
+
+	opts := []emailvalidator.OptFunc{
+		emailvalidator.WithDomainLists(lists),
+		emailvalidator.WithRoleAccountSeverity(emailvalidator.SeverityWarning, "info"),
+	}
+
+	steps := []struct {
+		input  string
+		scorer emailvalidator.WeightedScorer
+		score  float64
+	}{
+		{"user@example.com", emailvalidator.WeightedScorer{}, 0},
+		{"a@b@example.com", emailvalidator.WeightedScorer{}, 1},
+		{"user@mailinator.com", emailvalidator.WeightedScorer{}, 0.6},
+		{"info@mailinator.com", emailvalidator.WeightedScorer{}, 0.8},
+		{"info@mailinator.com", emailvalidator.WeightedScorer{WarningWeight: 0.5}, 1},
+		{"info@example.com", emailvalidator.WeightedScorer{Weights: map[emailvalidator.Flag]float64{emailvalidator.FlagRole: 0.9}}, 0.9},
+	}
+	for _, step := range steps {
+		res, _ := emailvalidator.BuildResult(step.input, opts...)
+		score, err := step.scorer.Score(context.Background(), res)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", step.input, err)
+		}
+		if math.Abs(score-step.score) > 1e-9 {
+			t.Errorf("%s: expected score %v, saw %v", step.input, step.score, score)
+		}
+	}
+}
+
+func TestScorerFunc(t *testing.T) {
+	errUnavailable := errors.New("scoring service unavailable")
+
+	var scorer emailvalidator.Scorer = emailvalidator.ScorerFunc(func(ctx context.Context, res emailvalidator.Result) (float64, error) {
+		if res.Domain == "example.org" {
+			return 0, errUnavailable
+		}
+		return 0.5, nil
+	})
+
+	res, _ := emailvalidator.BuildResult("user@example.com")
+	if score, err := scorer.Score(context.Background(), res); err != nil || score != 0.5 {
+		t.Errorf("expected 0.5, saw %v and %v", score, err)
+	}
+
+	res, _ = emailvalidator.BuildResult("user@example.org")
+	if _, err := scorer.Score(context.Background(), res); !errors.Is(err, errUnavailable) {
+		t.Errorf("expected errUnavailable, saw %v", err)
+	}
+}
+
+func TestWeightedScorer_Deterministic(t *testing.T) {
+	res, _ := emailvalidator.BuildResult(`"in@fo"(c)@[127.0.0.1]`)
+	scorer := emailvalidator.WeightedScorer{Weights: map[emailvalidator.Flag]float64{
+		emailvalidator.FlagQuoted:         0.1,
+		emailvalidator.FlagComment:        0.2,
+		emailvalidator.FlagAddressLiteral: 0.3,
+		emailvalidator.FlagQuotedAt:       1e-17,
+	}}
+
+	first, _ := scorer.Score(context.Background(), res)
+	for i := 0; i < 100; i++ {
+		if score, _ := scorer.Score(context.Background(), res); score != first {
+			t.Fatalf("expected identical scores, saw %v and %v", first, score)
+		}
+	}
+}
+
+func TestWeightedScorer_RemoteResult(t *testing.T) {
+	srv := httptest.NewServer(emailvalidator.NewHandler(emailvalidator.NewValidator()))
+	defer srv.Close()
+
+	res, _ := emailvalidator.NewClient(srv.URL, srv.Client()).Validate(context.Background(), "a@b@example.com")
+	if score, err := (emailvalidator.WeightedScorer{}).Score(context.Background(), res); err != nil || score != 1 {
+		t.Errorf("expected invalid remote result to score 1, saw %v and %v", score, err)
+	}
+}
@@ -0,0 +1,133 @@
+package emailvalidator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+var (
+	ErrSinkDelivery = errors.New("sink delivery failed")
+)
+
+// Sink receives each Result as it is produced by a bulk run, allowing results to be streamed elsewhere rather than
+// collected in memory.  Sinks that buffer results should also implement Flusher.
+type Sink interface {
+	OnResult(res Result) error
+}
+
+// Flusher is implemented by Sinks that buffer results.  Bulk runs call Flush once all results have been delivered.
+type Flusher interface {
+	Flush() error
+}
+
+// SinkFunc adapts a function to the Sink interface
+type SinkFunc func(res Result) error
+
+func (fn SinkFunc) OnResult(res Result) error {
+	return fn(res)
+}
+
+// WithSink sets the Sink each Result of a bulk run is delivered to
+func WithSink(sink Sink) BulkOptFunc {
+	return func(opt *BulkOptions) {
+		opt.Sink = sink
+	}
+}
+
+// flushSink flushes sink if it implements Flusher, returning err or any error seen during the flush
+func flushSink(sink Sink, err error) error {
+	if f, ok := sink.(Flusher); ok {
+		if ferr := f.Flush(); ferr != nil {
+			return errors.Join(err, ferr)
+		}
+	}
+	return err
+}
+
+// JSONLSink writes a JSON-encoded Report per line to an io.Writer, e.g. an *os.File.  It is safe for concurrent use.
+type JSONLSink struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+func NewJSONLSink(w io.Writer) *JSONLSink {
+	return &JSONLSink{enc: json.NewEncoder(w)}
+}
+
+func (s *JSONLSink) OnResult(res Result) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.enc.Encode(NewReport(res, res.Err, 0)); err != nil {
+		return fmt.Errorf("%w: %v", ErrSinkDelivery, err)
+	}
+	return nil
+}
+
+// WebhookSink POSTs batches of Reports to a URL as a JSON array.  If a delivery fails, the batch is kept and retried
+// with the next delivery.  It is safe for concurrent use.
+type WebhookSink struct {
+	mu         sync.Mutex
+	url        string
+	batchSize  int
+	httpClient *http.Client
+	batch      []Report
+}
+
+// NewWebhookSink creates a WebhookSink delivering batches of up to batchSize reports to url.  If httpClient is nil,
+// http.DefaultClient is used.
+func NewWebhookSink(url string, batchSize int, httpClient *http.Client) *WebhookSink {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	if batchSize < 1 {
+		batchSize = 1
+	}
+	return &WebhookSink{url: url, batchSize: batchSize, httpClient: httpClient}
+}
+
+func (s *WebhookSink) OnResult(res Result) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.batch = append(s.batch, NewReport(res, res.Err, 0))
+	if len(s.batch) < s.batchSize {
+		return nil
+	}
+	return s.deliver()
+}
+
+// Flush delivers any buffered reports
+func (s *WebhookSink) Flush() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.batch) == 0 {
+		return nil
+	}
+	return s.deliver()
+}
+
+func (s *WebhookSink) deliver() error {
+	body, err := json.Marshal(s.batch)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrSinkDelivery, err)
+	}
+
+	resp, err := s.httpClient.Post(s.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrSinkDelivery, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%w: status %d: %s", ErrSinkDelivery, resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+
+	s.batch = s.batch[:0]
+	return nil
+}
@@ -0,0 +1,143 @@
+package emailvalidator_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"testing/iotest"
+
+	emailvalidator "github.com/dcarbone/go-email-validator"
+)
+
+func TestJSONLSink(t *testing.T) {
+	var buf bytes.Buffer
+	_, err := emailvalidator.ValidateCSV(
+		context.Background(),
+		strings.NewReader("simple@example.com\nabc.example.com\n"),
+		nil,
+		0,
+		emailvalidator.WithSink(emailvalidator.NewJSONLSink(&buf)),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var verdicts []emailvalidator.Verdict
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var rep emailvalidator.Report
+		if err := dec.Decode(&rep); err != nil {
+			t.Fatalf("error decoding report: %v", err)
+		}
+		verdicts = append(verdicts, rep.Verdict)
+	}
+	if len(verdicts) != 2 || verdicts[0] != emailvalidator.VerdictValid || verdicts[1] != emailvalidator.VerdictInvalid {
+		t.Errorf("unexpected verdicts: %v", verdicts)
+	}
+}
+
+func TestWebhookSink(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		batches [][]emailvalidator.Report
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var batch []emailvalidator.Report
+		if err := json.NewDecoder(r.Body).Decode(&batch); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		batches = append(batches, batch)
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	_, err := emailvalidator.WriteReports(
+		context.Background(),
+		strings.NewReader("a@example.com\nb@example.com\nc@example.com\n"),
+		new(bytes.Buffer),
+		emailvalidator.WithSink(emailvalidator.NewWebhookSink(srv.URL, 2, srv.Client())),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(batches) != 2 || len(batches[0]) != 2 || len(batches[1]) != 1 {
+		t.Fatalf("unexpected batches: %+v", batches)
+	}
+	if batches[1][0].Input != "c@example.com" {
+		t.Errorf("unexpected final report: %+v", batches[1][0])
+	}
+}
+
+func TestWebhookSink_Failure(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "nope", http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	sink := emailvalidator.NewWebhookSink(srv.URL, 10, srv.Client())
+	res, _ := emailvalidator.BuildResult("a@example.com")
+	if err := sink.OnResult(res); err != nil {
+		t.Fatalf("unexpected error before batch is full: %v", err)
+	}
+	if err := sink.Flush(); !errors.Is(err, emailvalidator.ErrSinkDelivery) {
+		t.Errorf("expected ErrSinkDelivery, saw %v", err)
+	}
+}
+
+func TestSink_FlushedOnEarlyExit(t *testing.T) {
+	const input = "a@example.com\nb@example.com\nc@example.com\nd@example.com\n"
+	errRead := errors.New("read failed")
+
+	runs := map[string]func(ctx context.Context, opts ...emailvalidator.BulkOptFunc) error{
+		"csv-cancelled": func(ctx context.Context, opts ...emailvalidator.BulkOptFunc) error {
+			_, err := emailvalidator.ValidateCSV(ctx, strings.NewReader(input), nil, 0, opts...)
+			return err
+		},
+		"csv-read-error": func(ctx context.Context, opts ...emailvalidator.BulkOptFunc) error {
+			r := io.MultiReader(strings.NewReader("a@example.com\nb@example.com\n"), iotest.ErrReader(errRead))
+			_, err := emailvalidator.ValidateCSV(ctx, r, nil, 0, opts...)
+			return err
+		},
+		"reports-cancelled": func(ctx context.Context, opts ...emailvalidator.BulkOptFunc) error {
+			_, err := emailvalidator.WriteReports(ctx, strings.NewReader(input), io.Discard, opts...)
+			return err
+		},
+		"reports-read-error": func(ctx context.Context, opts ...emailvalidator.BulkOptFunc) error {
+			r := io.MultiReader(strings.NewReader("a@example.com\nb@example.com\n"), iotest.ErrReader(errRead))
+			opts = append(opts, emailvalidator.WithInputFormat(emailvalidator.FormatLines, ""))
+			_, err := emailvalidator.WriteReports(ctx, r, io.Discard, opts...)
+			return err
+		},
+	}
+	for label, run := range runs {
+		t.Run(label, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			sink := new(bufferingSink)
+			err := run(ctx, emailvalidator.WithSink(sink), emailvalidator.WithProgress(func(done, _ int) {
+				if done == 2 {
+					cancel()
+				}
+			}))
+			if !errors.Is(err, context.Canceled) && !errors.Is(err, errRead) {
+				t.Fatalf("expected the run to end early, saw %v", err)
+			}
+			if sink.delivered != 2 || sink.pending != 0 {
+				t.Errorf("expected 2 results delivered, saw %d delivered and %d pending", sink.delivered, sink.pending)
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package emailvalidator
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// confusables maps characters to the prototype they are visually confusable with.  It is the subset of the Unicode
+// confusables data (UTS #39) covering characters commonly used to imitate lower-case Latin domains.
+var confusables = map[rune]string{
+	// Latin and ASCII
+	'0':      "o",
+	'1':      "l",
+	'|':      "l",
+	'\u0131': "i", // latin small letter dotless i
+	'\u0237': "j", // latin small letter dotless j
+	'\u0251': "a", // latin small letter alpha
+	'\u0261': "g", // latin small letter script g
+	'\u0269': "i", // latin small letter iota
+	'\u2113': "l", // script small l
+
+	// Greek
+	'\u03b1': "a", // greek small letter alpha
+	'\u03b9': "i", // greek small letter iota
+	'\u03bd': "v", // greek small letter nu
+	'\u03bf': "o", // greek small letter omicron
+	'\u03c1': "p", // greek small letter rho
+	'\u03f2': "c", // greek lunate sigma symbol
+	'\u03f3': "j", // greek letter yot
+
+	// Cyrillic
+	'\u0430': "a", // cyrillic small letter a
+	'\u0435': "e", // cyrillic small letter ie
+	'\u043e': "o", // cyrillic small letter o
+	'\u0440': "p", // cyrillic small letter er
+	'\u0441': "c", // cyrillic small letter es
+	'\u0443': "y", // cyrillic small letter u
+	'\u0445': "x", // cyrillic small letter ha
+	'\u0455': "s", // cyrillic small letter dze
+	'\u0456': "i", // cyrillic small letter byelorussian-ukrainian i
+	'\u0458': "j", // cyrillic small letter je
+	'\u04af': "y", // cyrillic small letter straight u
+	'\u04bb': "h", // cyrillic small letter shha
+	'\u04cf': "l", // cyrillic small letter palochka
+	'\u0501': "d", // cyrillic small letter komi de
+	'\u051b': "q", // cyrillic small letter qa
+	'\u051d': "w", // cyrillic small letter we
+
+	// dashes
+	'\u2010': "-", // hyphen
+	'\u2011': "-", // non-breaking hyphen
+	'\u2012': "-", // figure dash
+	'\u2013': "-", // en dash
+	'\u2212': "-", // minus sign
+}
+
+// Skeleton returns the UTS #39 skeleton of domain, such that two domains with equal skeletons are visually
+// confusable, e.g. "xn--80ak6aa92e.com" (Cyrillic "аррӏе.com") and "apple.com" both produce "apple.com".  Skeletons
+// are intended as keys, e.g. for a blocklist of confusable domains, and are not suitable for display.
+//
+// As domains are case-insensitive, domain is lower-cased before mapping and prototypes are lower-case.  Labels
+// beginning with "xn--" are decoded from Punycode first.  Full-width characters are mapped to their ASCII forms, and
+// other characters are mapped using the subset of the Unicode confusables data covering common imitations of Latin
+// letters.  Input is expected to be NFC-normalized, as IDNA requires.
+func Skeleton(domain string) string {
+	labels := strings.Split(strings.ToLower(domain), ".")
+	for i, label := range labels {
+		if strings.HasPrefix(label, "xn--") {
+			if decoded, ok := decodePunycode(label[4:]); ok {
+				label = decoded
+			}
+		}
+
+		var b strings.Builder
+		for _, r := range label {
+			// full-width forms occupy a contiguous block mirroring ASCII
+			if r >= '\uff01' && r <= '\uff5e' {
+				r -= 0xfee0
+				if r >= 'A' && r <= 'Z' {
+					r += 'a' - 'A'
+				}
+			}
+			if proto, ok := confusables[r]; ok {
+				b.WriteString(proto)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+		// UTS #39 maps "m" to "rn"; the reverse is applied so keys remain readable, with the same effect on equality
+		labels[i] = strings.ReplaceAll(b.String(), "rn", "m")
+	}
+	return strings.Join(labels, ".")
+}
+
+// Punycode parameters, per RFC 3492
+const (
+	punyBase        = 36
+	punyTMin        = 1
+	punyTMax        = 26
+	punySkew        = 38
+	punyDamp        = 700
+	punyInitialBias = 72
+	punyInitialN    = 128
+)
+
+// decodePunycode decodes the Punycode-encoded label s, sans "xn--" prefix, returning false if s is malformed
+func decodePunycode(s string) (string, bool) {
+	var (
+		output []rune
+		n      = punyInitialN
+		i      = 0
+		bias   = punyInitialBias
+	)
+	if d := strings.LastIndexByte(s, '-'); d >= 0 {
+		output = []rune(s[:d])
+		s = s[d+1:]
+	}
+
+	for pos := 0; pos < len(s); {
+		oldI, w := i, 1
+		for k := punyBase; ; k += punyBase {
+			if pos >= len(s) {
+				return "", false
+			}
+			digit := punyDigit(s[pos])
+			pos++
+			if digit < 0 || digit > (utf8.MaxRune-i)/w {
+				return "", false
+			}
+			i += digit * w
+
+			t := k - bias
+			if t < punyTMin {
+				t = punyTMin
+			} else if t > punyTMax {
+				t = punyTMax
+			}
+			if digit < t {
+				break
+			}
+			w *= punyBase - t
+		}
+
+		count := len(output) + 1
+		bias = punyAdapt(i-oldI, count, oldI == 0)
+		n += i / count
+		i %= count
+		if n > utf8.MaxRune {
+			return "", false
+		}
+		output = append(output[:i], append([]rune{rune(n)}, output[i:]...)...)
+		i++
+	}
+	return string(output), true
+}
+
+// punyDigit returns the value of the Punycode digit c, or -1
+func punyDigit(c byte) int {
+	switch {
+	case c >= '0' && c <= '9':
+		return int(c-'0') + 26
+	case c >= 'a' && c <= 'z':
+		return int(c - 'a')
+	case c >= 'A' && c <= 'Z':
+		return int(c - 'A')
+	default:
+		return -1
+	}
+}
+
+// punyAdapt is the bias adaptation function of RFC 3492 section 6.1
+func punyAdapt(delta, count int, first bool) int {
+	if first {
+		delta /= punyDamp
+	} else {
+		delta /= 2
+	}
+	delta += delta / count
+	k := 0
+	for delta > ((punyBase-punyTMin)*punyTMax)/2 {
+		delta /= punyBase - punyTMin
+		k += punyBase
+	}
+	return k + (punyBase-punyTMin+1)*delta/(delta+punySkew)
+}
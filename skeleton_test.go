@@ -0,0 +1,32 @@
+package emailvalidator_test
+
+import (
+	"testing"
+
+	emailvalidator "github.com/dcarbone/go-email-validator"
+)
+
+func TestSkeleton(t *testing.T) {
+	tests := []struct {
+		domain string
+		want   string
+	}{
+		{"apple.com", "apple.com"},
+		{"xn--80ak6aa92e.com", "apple.com"},
+		{"XN--80AK6AA92E.COM", "apple.com"},
+		{"xn--ggle-55da.com", "google.com"},
+		{"xn--oogle-qmc.com", "google.com"},
+		{"g00gle.com", "google.com"},
+		{"rnicrosoft.com", "microsoft.com"},
+		{"paypa1.com", "paypal.com"},
+		{"\uff45\uff58\uff41\uff4d\uff50\uff4c\uff45.com", "example.com"},
+		{"xn--l-7sba6dbr.com", "paypal.com"},
+		{"xn--99999999.com", "xn--99999999.com"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := emailvalidator.Skeleton(tt.domain); got != tt.want {
+			t.Errorf("Skeleton(%q): expected %q, saw %q", tt.domain, tt.want, got)
+		}
+	}
+}
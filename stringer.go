@@ -0,0 +1,40 @@
+package emailvalidator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// summaryValue returns s as-is if it may be embedded within a summary line unambiguously, or quoted otherwise
+func summaryValue(s string) string {
+	if s == "" || strings.ContainsAny(s, " \"=;[]") || strings.IndexFunc(s, func(r rune) bool {
+		return !unicode.IsGraphic(r)
+	}) != -1 {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// summaryLine renders the single-line summary shared by Result and Report
+func summaryLine(verdict Verdict, local, domain string, flags Flags, errs, warnings int) string {
+	var b strings.Builder
+	_, _ = fmt.Fprintf(&b, "%s; local=%s domain=%s flags=[%s]", verdict, summaryValue(local), summaryValue(domain), flags)
+	if errs > 0 {
+		_, _ = fmt.Fprintf(&b, " errors=%d", errs)
+	}
+	_, _ = fmt.Fprintf(&b, " warnings=%d", warnings)
+	return b.String()
+}
+
+// String returns a single-line summary of r suitable for logging, e.g.
+// "valid; local=jane domain=example.com flags=[role] warnings=1".  Error counts are only included when invalid.
+func (r Result) String() string {
+	return summaryLine(VerdictOf(r.Err), r.Local, r.Domain, r.Flags, len(ErrorListOf(r.Err)), len(r.Warnings))
+}
+
+// String returns the same single-line summary as Result.String
+func (r Report) String() string {
+	return summaryLine(r.Verdict, r.Local, r.Domain, r.Flags, len(r.Errors), len(r.Warnings))
+}
@@ -0,0 +1,37 @@
+package emailvalidator_test
+
+import (
+	"testing"
+	"time"
+
+	emailvalidator "github.com/dcarbone/go-email-validator"
+)
+
+func TestResult_String(t *testing.T) {
+	opts := []emailvalidator.OptFunc{
+		emailvalidator.WithRoleAccountSeverity(emailvalidator.SeverityWarning, "sales"),
+		func(opt *emailvalidator.ParseOptions) { opt.SubAddressSeparators = "+" },
+	}
+
+	steps := []struct {
+		input string
+		out   string
+	}{
+		{input: "jane@example.com", out: "valid; local=jane domain=example.com flags=[] warnings=0"},
+		{input: "sales+leads@example.com", out: "valid; local=sales+leads domain=example.com flags=[role,sub_address] warnings=1"},
+		{input: `"jane doe"@example.com`, out: `valid; local="\"jane doe\"" domain=example.com flags=[quoted] warnings=0`},
+		{input: "jane doe@", out: `invalid; local=janedoe domain="" flags=[] errors=2 warnings=0`},
+	}
+
+	for _, step := range steps {
+		t.Run(step.input, func(t *testing.T) {
+			res, err := emailvalidator.BuildResult(step.input, opts...)
+			if out := res.String(); out != step.out {
+				t.Errorf("expected %q, saw %q", step.out, out)
+			}
+			if out := emailvalidator.NewReport(res, err, time.Millisecond).String(); out != step.out {
+				t.Errorf("expected report %q, saw %q", step.out, out)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package emailvalidator
+
+import (
+	"strings"
+)
+
+// isAtext returns true if c is an RFC 5322 atext character
+func isAtext(c byte) bool {
+	switch {
+	case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		return true
+	}
+	return strings.IndexByte("!#$%&'*+-/=?^_`{|}~", c) != -1
+}
+
+// isDotAtom returns true if s is a valid RFC 5322 dot-atom-text: one or more atext characters, optionally separated by
+// single periods.
+func isDotAtom(s string) bool {
+	if s == "" || s[0] == '.' || s[len(s)-1] == '.' {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] == '.' {
+			if s[i-1] == '.' {
+				return false
+			}
+		} else if !isAtext(s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// isQuotedString returns true if s is a single quoted-string, i.e. it begins with '"' and its first unescaped closing
+// '"' is its final character
+func isQuotedString(s string) bool {
+	if len(s) < 2 || s[0] != '"' {
+		return false
+	}
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i == len(s)-1
+		}
+	}
+	return false
+}
+
+// unquoteLocal returns the semantic value of a local part, with quoting and quoted-pair escapes removed
+func unquoteLocal(local string) string {
+	var (
+		sb      strings.Builder
+		inQuote bool
+	)
+	for i := 0; i < len(local); i++ {
+		c := local[i]
+		switch {
+		case c == '"':
+			inQuote = !inQuote
+		case c == '\\' && inQuote && i+1 < len(local):
+			i++
+			sb.WriteByte(local[i])
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String()
+}
+
+// minimalLocal returns the smallest RFC-valid representation of local.  Unquoted locals are returned as-is, unless
+// they end in the empty atom BehaviorVersion1 allows, e.g. "user.", in which case they are quoted.  Quoted locals are
+// unquoted entirely if their value is a dot-atom, otherwise they are re-quoted as a single quoted-string with only '"'
+// and '\' escaped.
+func minimalLocal(local string, quoted bool) string {
+	value := local
+	if quoted {
+		value = unquoteLocal(local)
+	}
+	if isDotAtom(value) || (!quoted && local == "") {
+		return value
+	}
+
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for i := 0; i < len(value); i++ {
+		if c := value[i]; c == '"' || c == '\\' {
+			sb.WriteByte('\\')
+		}
+		sb.WriteByte(value[i])
+	}
+	sb.WriteByte('"')
+	return sb.String()
+}
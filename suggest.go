@@ -0,0 +1,310 @@
+package emailvalidator
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// DefaultSuggestionDomains contains widely used mailbox provider domains, ordered roughly by popularity.  Order is
+// used to break ties between equally distant candidates.
+var DefaultSuggestionDomains = []string{
+	"gmail.com",
+	"yahoo.com",
+	"hotmail.com",
+	"outlook.com",
+	"icloud.com",
+	"aol.com",
+	"live.com",
+	"msn.com",
+	"me.com",
+	"mac.com",
+	"comcast.net",
+	"protonmail.com",
+	"proton.me",
+	"gmx.com",
+	"gmx.de",
+	"mail.com",
+	"yandex.com",
+	"zoho.com",
+	"hotmail.co.uk",
+	"yahoo.co.uk",
+	"googlemail.com",
+}
+
+// DefaultKnownDomains contains legitimate mailbox provider domains that lie within a typo of a
+// DefaultSuggestionDomains candidate, e.g. "ymail.com" and "gmail.com".  Suggestions for them are only ever of
+// ConfidenceLow.
+var DefaultKnownDomains = []string{
+	"ymail.com",
+	"rocketmail.com",
+	"aim.com",
+	"aol.co.uk",
+	"gmx.net",
+	"gmx.at",
+	"gmx.ch",
+	"live.ca",
+	"live.co.uk",
+	"live.fr",
+	"live.de",
+	"live.nl",
+	"yahoo.ca",
+	"yahoo.fr",
+	"yahoo.de",
+	"yahoo.es",
+	"yahoo.it",
+	"yahoo.in",
+	"yahoo.co.in",
+	"yahoo.co.jp",
+	"yahoo.com.au",
+	"yahoo.com.br",
+	"hotmail.fr",
+	"hotmail.de",
+	"hotmail.es",
+	"hotmail.it",
+	"outlook.fr",
+	"outlook.de",
+	"mail.ru",
+	"email.com",
+	"zoho.eu",
+	"yandex.ru",
+}
+
+// DistanceFunc returns the distance between a and b.  Lower values indicate more similar strings, and identical
+// strings must have a distance of 0.
+type DistanceFunc func(a, b string) float64
+
+// Levenshtein returns the number of single-byte insertions, deletions, and substitutions required to turn a into b
+func Levenshtein(a, b string) float64 {
+	if a == b {
+		return 0
+	}
+
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return float64(prev[len(b)])
+}
+
+// Suggestion is a single candidate correction
+type Suggestion struct {
+	// Domain is the suggested domain
+	Domain string
+
+	// Address is the full suggested address.  It is empty when suggesting for a bare domain.
+	Address string
+
+	// Distance is the distance between the input domain and Domain, as computed by the Suggester's DistanceFunc
+	Distance float64
+
+	// Confidence is the likelihood that the suggestion is correct, from 0 to 1.  It is the proportion of Domain left
+	// unchanged by Distance, reduced by how close the nearest other candidate is, such that equally close candidates
+	// are at most half as likely.  If the input domain is a known domain, Confidence is capped below ConfidenceMedium.
+	Confidence float64
+}
+
+// Level returns the ConfidenceLevel of s
+func (s Suggestion) Level() ConfidenceLevel {
+	return ConfidenceLevelOf(s.Confidence)
+}
+
+type SuggesterOptions struct {
+	// Distance is used to compare input domains against candidates.  Defaults to KeyboardDistance.
+	Distance DistanceFunc
+
+	// Threshold is the maximum distance at which a candidate is suggested.  Defaults to 2.
+	Threshold float64
+
+	// Domains contains the candidate domains.  Defaults to DefaultSuggestionDomains.
+	Domains []string
+
+	// Known contains legitimate domains that are not themselves candidates, but that suggestions should rarely
+	// replace.  Defaults to DefaultKnownDomains.
+	Known []string
+
+	// MaxSuggestions, if greater than zero, caps the number of suggestions returned
+	MaxSuggestions int
+}
+
+type SuggesterOptFunc func(*SuggesterOptions)
+
+func WithDistanceFunc(fn DistanceFunc) SuggesterOptFunc {
+	return func(opt *SuggesterOptions) {
+		opt.Distance = fn
+	}
+}
+
+func WithThreshold(threshold float64) SuggesterOptFunc {
+	return func(opt *SuggesterOptions) {
+		opt.Threshold = threshold
+	}
+}
+
+// WithDomains replaces the candidate domain list, e.g. with the most common domains in a customer base
+func WithDomains(domains ...string) SuggesterOptFunc {
+	return func(opt *SuggesterOptions) {
+		opt.Domains = domains
+	}
+}
+
+// WithAdditionalDomains appends to the candidate domain list
+func WithAdditionalDomains(domains ...string) SuggesterOptFunc {
+	return func(opt *SuggesterOptions) {
+		if opt.Domains == nil {
+			opt.Domains = append([]string(nil), DefaultSuggestionDomains...)
+		}
+		opt.Domains = append(opt.Domains, domains...)
+	}
+}
+
+// WithKnownDomains replaces the list of known domains, e.g. with the regional domains of providers within Domains.
+// Calling it with no domains disables the cap on suggestions for known domains.
+func WithKnownDomains(domains ...string) SuggesterOptFunc {
+	return func(opt *SuggesterOptions) {
+		opt.Known = append([]string{}, domains...)
+	}
+}
+
+func WithMaxSuggestions(n int) SuggesterOptFunc {
+	return func(opt *SuggesterOptions) {
+		opt.MaxSuggestions = n
+	}
+}
+
+// Suggester proposes corrections for likely-mistyped domains
+type Suggester struct {
+	opts  SuggesterOptions
+	known map[string]struct{}
+}
+
+func NewSuggester(opts ...SuggesterOptFunc) *Suggester {
+	s := new(Suggester)
+	for _, fn := range opts {
+		fn(&s.opts)
+	}
+	if s.opts.Distance == nil {
+		s.opts.Distance = KeyboardDistance
+	}
+	if s.opts.Threshold <= 0 {
+		s.opts.Threshold = 2
+	}
+	if s.opts.Domains == nil {
+		s.opts.Domains = DefaultSuggestionDomains
+	}
+	// normalize candidates once, so comparisons are case-insensitive
+	domains := make([]string, len(s.opts.Domains))
+	for i, d := range s.opts.Domains {
+		domains[i] = strings.ToLower(d)
+	}
+	s.opts.Domains = domains
+
+	if s.opts.Known == nil {
+		s.opts.Known = DefaultKnownDomains
+	}
+	s.known = make(map[string]struct{}, len(s.opts.Known))
+	for _, d := range s.opts.Known {
+		s.known[strings.ToLower(d)] = struct{}{}
+	}
+	return s
+}
+
+// SuggestDomain returns candidate domains within the threshold distance of domain, closest first.  If domain is
+// itself a candidate, no suggestions are returned.
+func (s *Suggester) SuggestDomain(domain string) []Suggestion {
+	domain = strings.ToLower(domain)
+
+	var (
+		distances = make([]float64, len(s.opts.Domains))
+		nearest   = -1
+		runnerUp  = math.Inf(1)
+	)
+	for i, candidate := range s.opts.Domains {
+		if candidate == domain {
+			return nil
+		}
+		distances[i] = s.opts.Distance(domain, candidate)
+		if nearest < 0 || distances[i] < distances[nearest] {
+			if nearest >= 0 {
+				runnerUp = distances[nearest]
+			}
+			nearest = i
+		} else if distances[i] < runnerUp {
+			runnerUp = distances[i]
+		}
+	}
+
+	_, known := s.known[domain]
+
+	var out []Suggestion
+	for i, candidate := range s.opts.Domains {
+		d := distances[i]
+		if d > s.opts.Threshold {
+			continue
+		}
+		// the nearest other candidate is the runner-up for the nearest candidate, and the nearest for all others
+		other := distances[nearest]
+		if i == nearest {
+			other = runnerUp
+		}
+		out = append(out, Suggestion{
+			Domain:     candidate,
+			Distance:   d,
+			Confidence: suggestionConfidence(d, other, candidate, known),
+		})
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].Distance < out[j].Distance
+	})
+
+	if s.opts.MaxSuggestions > 0 && len(out) > s.opts.MaxSuggestions {
+		out = out[:s.opts.MaxSuggestions]
+	}
+
+	return out
+}
+
+// isKnown returns true if domain is one of the Suggester's candidates or known domains
+func (s *Suggester) isKnown(domain string) bool {
+	domain = strings.ToLower(domain)
+	if _, ok := s.known[domain]; ok {
+		return true
+	}
+	for _, candidate := range s.opts.Domains {
+		if candidate == domain {
+			return true
+		}
+	}
+	return false
+}
+
+// Suggest parses email and returns suggestions for its domain, with Address populated.  Literal domains and
+// addresses with no domain produce no suggestions.
+func (s *Suggester) Suggest(email string, opts ...OptFunc) []Suggestion {
+	res, _ := BuildResult(email, opts...)
+	if res.Domain == "" || res.LiteralDomain {
+		return nil
+	}
+
+	out := s.SuggestDomain(res.Domain)
+	for i := range out {
+		out[i].Address = fmt.Sprintf("%s@%s", res.Stripped[:strings.LastIndexByte(res.Stripped, '@')], out[i].Domain)
+	}
+	return out
+}
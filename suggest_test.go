@@ -0,0 +1,63 @@
+package emailvalidator_test
+
+import (
+	"testing"
+
+	emailvalidator "github.com/dcarbone/go-email-validator"
+)
+
+func TestLevenshtein(t *testing.T) {
+	steps := []struct {
+		a, b     string
+		expected float64
+	}{
+		{"", "", 0},
+		{"gmail.com", "gmail.com", 0},
+		{"gmial.com", "gmail.com", 2},
+		{"gmai.com", "gmail.com", 1},
+		{"kitten", "sitting", 3},
+		{"", "abc", 3},
+	}
+	for _, step := range steps {
+		if d := emailvalidator.Levenshtein(step.a, step.b); d != step.expected {
+			t.Errorf("Levenshtein(%q, %q): expected %v, saw %v", step.a, step.b, step.expected, d)
+		}
+	}
+}
+
+func TestSuggester(t *testing.T) {
+	s := emailvalidator.NewSuggester()
+
+	if out := s.Suggest("user@gmail.com"); len(out) != 0 {
+		t.Errorf("expected no suggestions for exact match, saw %v", out)
+	}
+
+	out := s.Suggest("user@gmai.com")
+	if len(out) == 0 {
+		t.Fatal("expected suggestions")
+	}
+	if out[0].Domain != "gmail.com" || out[0].Address != "user@gmail.com" || out[0].Distance != 1 {
+		t.Errorf("unexpected top suggestion: %+v", out[0])
+	}
+	for i := 1; i < len(out); i++ {
+		if out[i].Distance < out[i-1].Distance {
+			t.Errorf("suggestions not ranked: %v", out)
+		}
+	}
+}
+
+func TestSuggester_CustomDomains(t *testing.T) {
+	s := emailvalidator.NewSuggester(
+		emailvalidator.WithDomains("example-corp.com"),
+		emailvalidator.WithThreshold(1),
+		emailvalidator.WithMaxSuggestions(1),
+	)
+
+	out := s.SuggestDomain("EXAMPLE-CORP.CM")
+	if len(out) != 1 || out[0].Domain != "example-corp.com" {
+		t.Errorf("unexpected suggestions: %v", out)
+	}
+	if out := s.SuggestDomain("gmai.com"); len(out) != 0 {
+		t.Errorf("expected default domains to be replaced, saw %v", out)
+	}
+}
@@ -0,0 +1,146 @@
+package emailvalidator
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"math"
+	"os"
+	"strings"
+	"sync"
+)
+
+// SuppressionHash returns the hex-encoded SHA-256 digest of email's normalized form, as computed by NormalizeKey.
+// This is the form entries must take within files loaded by SuppressionList.Load.
+func SuppressionHash(email string) (string, error) {
+	res, err := BuildResult(email)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(NormalizeKey(res)))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// SuppressionList is a bloom filter of hashed addresses known to be undeliverable or unwanted, e.g. hard bounces and
+// complaints.  It never stores plaintext addresses, or even their full hashes.  As with any bloom filter, lookups may
+// produce false positives at roughly the configured rate, but never false negatives.
+//
+// A SuppressionList is safe for concurrent use.
+type SuppressionList struct {
+	mu   sync.RWMutex
+	bits []uint64
+	k    uint64
+}
+
+// NewSuppressionList creates a SuppressionList sized to hold n entries with a false positive rate of approximately
+// fpRate, e.g. 0.001.
+func NewSuppressionList(n int, fpRate float64) *SuppressionList {
+	n = max(n, 1)
+	if fpRate <= 0 || fpRate >= 1 {
+		fpRate = 0.001
+	}
+	m := math.Ceil(-float64(n) * math.Log(fpRate) / (math.Ln2 * math.Ln2))
+	k := math.Round(m / float64(n) * math.Ln2)
+	return &SuppressionList{
+		bits: make([]uint64, (uint64(m)+63)/64),
+		k:    uint64(max(k, 1)),
+	}
+}
+
+// Add inserts the SHA-256 digest sum into s
+func (s *SuppressionList) Add(sum [sha256.Size]byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, idx := range s.indexes(sum) {
+		s.bits[idx/64] |= 1 << (idx % 64)
+	}
+}
+
+// Contains returns true if the SHA-256 digest sum has likely been added to s
+func (s *SuppressionList) Contains(sum [sha256.Size]byte) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, idx := range s.indexes(sum) {
+		if s.bits[idx/64]&(1<<(idx%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// indexes returns the k bit positions of sum.  The digest is already uniformly distributed, so its first two words
+// are used directly for double hashing.
+func (s *SuppressionList) indexes(sum [sha256.Size]byte) []uint64 {
+	var (
+		m   = uint64(len(s.bits)) * 64
+		h1  = binary.BigEndian.Uint64(sum[0:8])
+		h2  = binary.BigEndian.Uint64(sum[8:16]) | 1
+		out = make([]uint64, s.k)
+	)
+	for i := range out {
+		out[i] = (h1 + uint64(i)*h2) % m
+	}
+	return out
+}
+
+// Load adds the hex-encoded SHA-256 digests read from r, one per line, as produced by SuppressionHash.  Blank lines and
+// lines beginning with "#" are skipped.  Entries read before an error remain within s.
+func (s *SuppressionList) Load(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		var sum [sha256.Size]byte
+		if len(text) != hex.EncodedLen(sha256.Size) {
+			return fmt.Errorf("line %d is not a hex-encoded sha256 digest", line)
+		} else if _, err := hex.Decode(sum[:], []byte(text)); err != nil {
+			return fmt.Errorf("line %d is not a hex-encoded sha256 digest", line)
+		}
+		s.Add(sum)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("error reading suppression list: %w", err)
+	}
+	return nil
+}
+
+// LoadFile calls Load with the contents of the file at path
+func (s *SuppressionList) LoadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("error opening suppression list: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+	return s.Load(f)
+}
+
+// LoadFS calls Load with the contents of the file name within fsys, e.g. an embed.FS bundled into the binary
+func (s *SuppressionList) LoadFS(fsys fs.FS, name string) error {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return fmt.Errorf("error opening suppression list: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+	return s.Load(f)
+}
+
+// WithSuppressionList sets the SuppressionList used to populate Result.Suppressed
+func WithSuppressionList(list *SuppressionList) OptFunc {
+	return func(opt *ParseOptions) {
+		opt.SuppressionList = list
+	}
+}
+
+// checkSuppressed sets res.Suppressed if the address is within the configured SuppressionList
+func checkSuppressed(res *Result, opts *ParseOptions) {
+	if opts.SuppressionList == nil || res.Local == "" || res.Domain == "" {
+		return
+	}
+	res.Suppressed = opts.SuppressionList.Contains(sha256.Sum256([]byte(NormalizeKey(*res))))
+}
@@ -0,0 +1,98 @@
+package emailvalidator_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	emailvalidator "github.com/dcarbone/go-email-validator"
+)
+
+func TestSuppressionList(t *testing.T) {
+	var lines []string
+	for _, email := range []string{"bounced@example.com", "complained@Example.COM"} {
+		hash, err := emailvalidator.SuppressionHash(email)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		lines = append(lines, hash)
+	}
+
+	list := emailvalidator.NewSuppressionList(len(lines), 0.001)
+	if err := list.Load(strings.NewReader("# hard bounces\n\n" + strings.Join(lines, "\n"))); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	steps := []struct {
+		input      string
+		suppressed bool
+	}{
+		{input: "bounced@example.com", suppressed: true},
+		{input: "bounced@EXAMPLE.com", suppressed: true},
+		{input: `"complained"@example.com`, suppressed: true},
+		{input: "delivered@example.com"},
+		{input: "Bounced@example.com"},
+	}
+
+	for _, step := range steps {
+		t.Run(step.input, func(t *testing.T) {
+			res, err := emailvalidator.BuildResult(step.input, emailvalidator.WithSuppressionList(list))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if res.Suppressed != step.suppressed || res.Flags.Has(emailvalidator.FlagSuppressed) != step.suppressed {
+				t.Errorf("expected suppressed=%t, saw %t (flags %v)", step.suppressed, res.Suppressed, res.Flags)
+			}
+		})
+	}
+}
+
+func TestSuppressionList_FalsePositiveRate(t *testing.T) {
+	const n = 1000
+	list := emailvalidator.NewSuppressionList(n, 0.01)
+	var sb strings.Builder
+	for i := 0; i < n; i++ {
+		hash, _ := emailvalidator.SuppressionHash(fmt.Sprintf("user%d@example.com", i))
+		sb.WriteString(hash + "\n")
+	}
+	if err := list.Load(strings.NewReader(sb.String())); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var positives int
+	for i := 0; i < n; i++ {
+		res, _ := emailvalidator.BuildResult(fmt.Sprintf("other%d@example.com", i), emailvalidator.WithSuppressionList(list))
+		if res.Suppressed {
+			positives++
+		}
+	}
+	if positives > n/20 {
+		t.Errorf("false positive rate too high: %d of %d", positives, n)
+	}
+}
+
+func TestSuppressionList_LoadInvalid(t *testing.T) {
+	list := emailvalidator.NewSuppressionList(1, 0.001)
+	for _, in := range []string{"bounced@example.com", strings.Repeat("zz", 32), strings.Repeat("ab", 40)} {
+		if err := list.Load(strings.NewReader(in)); err == nil {
+			t.Errorf("expected error loading %q", in)
+		}
+	}
+}
+
+func TestSuppressionList_LoadFS(t *testing.T) {
+	hash, _ := emailvalidator.SuppressionHash("bounced@example.com")
+	fsys := fstest.MapFS{"bounces.txt": {Data: []byte(hash + "\n")}}
+
+	list := emailvalidator.NewSuppressionList(1, 0.001)
+	if err := list.LoadFS(fsys, "bounces.txt"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res, _ := emailvalidator.BuildResult("bounced@example.com", emailvalidator.WithSuppressionList(list)); !res.Suppressed {
+		t.Error("expected address to be suppressed")
+	}
+	if err := list.LoadFS(fsys, "complaints.txt"); err == nil {
+		t.Error("expected error loading missing file")
+	}
+}
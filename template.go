@@ -0,0 +1,70 @@
+package emailvalidator
+
+import (
+	"html/template"
+	"strings"
+	"unicode/utf8"
+)
+
+// maskRun replaces the hidden portion of each masked segment.  It is a fixed length, so as to not leak the length of
+// what it replaces.
+const maskRun = "***"
+
+// Mask returns the address within res with all but the first character of its local part and domain, and the domain's
+// top-level label, hidden, e.g. "j***@e***.com" for "jane@example.com".  Address literals are hidden entirely.
+func Mask(res Result) string {
+	local := res.Local
+	if res.Quoted {
+		local = unquoteLocal(local)
+	}
+	if local == "" || res.Domain == "" {
+		return maskRun
+	}
+
+	masked := firstRune(local) + maskRun + "@"
+	if res.LiteralDomain {
+		return masked + "[" + maskRun + "]"
+	}
+	domain := strings.ToLower(res.Domain)
+	masked += firstRune(domain) + maskRun
+	if dot := strings.LastIndexByte(domain, '.'); dot > 0 {
+		masked += domain[dot:]
+	}
+	return masked
+}
+
+// firstRune returns the first character of s, so as to not split a multi-byte character
+func firstRune(s string) string {
+	_, size := utf8.DecodeRuneInString(s)
+	return s[:size]
+}
+
+// TemplateFuncs returns helpers for displaying stored addresses within html/template or text/template templates, each
+// parsing with opts:
+//
+//   - isValidEmail returns true if the address is valid
+//   - maskEmail returns the address as rendered by Mask, or a fully masked value if it is invalid
+//   - normalizeEmail returns the address as rendered by NormalizeKey, or the input verbatim if it is invalid
+func TemplateFuncs(opts ...OptFunc) template.FuncMap {
+	v := NewValidator(opts...)
+	return template.FuncMap{
+		"isValidEmail": func(email string) bool {
+			_, err := v.Parse(email)
+			return err == nil
+		},
+		"maskEmail": func(email string) string {
+			res, err := v.Parse(email)
+			if err != nil {
+				return maskRun
+			}
+			return Mask(res)
+		},
+		"normalizeEmail": func(email string) string {
+			res, err := v.Parse(email)
+			if err != nil {
+				return email
+			}
+			return NormalizeKey(res)
+		},
+	}
+}
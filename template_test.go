@@ -0,0 +1,67 @@
+package emailvalidator_test
+
+import (
+	"html/template"
+	"strings"
+	"testing"
+
+	emailvalidator "github.com/dcarbone/go-email-validator"
+)
+
+func TestMask(t *testing.T) {
+	steps := []struct {
+		input string
+		opts  []emailvalidator.OptFunc
+		out   string
+	}{
+		{input: "jane@example.com", out: "j***@e***.com"},
+		{input: "Jane.Doe@Mail.Example.CO.UK", out: "J***@m***.uk"},
+		{input: `"jane doe"@example.com`, out: "j***@e***.com"},
+		{input: "jane@[192.168.0.1]", out: "j***@[***]"},
+		{input: "jane@localhost", out: "j***@l***"},
+		// net/mail accepts the UTF-8 of RFC 6532
+		{
+			input: "\u00e9lise@\u00e9cole.fr",
+			opts:  []emailvalidator.OptFunc{emailvalidator.WithEngine(emailvalidator.NetMailEngine{})},
+			out:   "\u00e9***@\u00e9***.fr",
+		},
+	}
+
+	for _, step := range steps {
+		t.Run(step.input, func(t *testing.T) {
+			res, err := emailvalidator.BuildResult(step.input, step.opts...)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if out := emailvalidator.Mask(res); out != step.out {
+				t.Errorf("expected %q, saw %q", step.out, out)
+			}
+		})
+	}
+}
+
+func TestTemplateFuncs(t *testing.T) {
+	tmpl := template.Must(template.New("row").Funcs(emailvalidator.TemplateFuncs()).Parse(
+		`{{if isValidEmail .}}{{maskEmail .}} {{normalizeEmail .}}{{else}}invalid {{maskEmail .}} {{normalizeEmail .}}{{end}}`,
+	))
+
+	steps := []struct {
+		input string
+		out   string
+	}{
+		{input: "Jane@Example.COM", out: "J***@e***.com Jane@example.com"},
+		{input: "jane@@example.com", out: "invalid *** jane@@example.com"},
+	}
+
+	for _, step := range steps {
+		t.Run(step.input, func(t *testing.T) {
+			var b strings.Builder
+			if err := tmpl.Execute(&b, step.input); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if out := b.String(); out != step.out {
+				t.Errorf("expected %q, saw %q", step.out, out)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package emailvalidator
+
+import (
+	"context"
+	"maps"
+	"slices"
+)
+
+// EmailValidator is implemented by anything capable of validating a single address, whether in-process (Validator)
+// or remote (Client).
+type EmailValidator interface {
+	Validate(ctx context.Context, email string) (Result, error)
+}
+
+// Validator holds a reusable set of parse options.  Options are resolved once, when the Validator is created, and
+// are never modified afterward, so a Validator is safe for concurrent use by multiple goroutines.  Any mutable state
+// referenced by its options, such as DomainLists, is synchronized internally.
+type Validator struct {
+	opts ParseOptions
+}
+
+// NewValidator resolves opts into a new Validator.  Maps and slices within the resolved options, e.g. RoleAccounts,
+// are copied, so the caller may go on to modify its own without affecting the Validator.
+func NewValidator(opts ...OptFunc) *Validator {
+	v := new(Validator)
+	for _, fn := range opts {
+		fn(&v.opts)
+	}
+	v.opts.Messages = maps.Clone(v.opts.Messages)
+	v.opts.RoleAccounts = maps.Clone(v.opts.RoleAccounts)
+	v.opts.ReservedWords = slices.Clone(v.opts.ReservedWords)
+	return v
+}
+
+// Parse calls BuildResult with the Validator's options.  Any provided opts are applied on top of the Validator's for
+// this call only, e.g. to relax or tighten a shared Validator for a single endpoint.
+func (v *Validator) Parse(email string, opts ...OptFunc) (Result, error) {
+	return BuildResult(email, append([]OptFunc{WithOptions(v.opts)}, opts...)...)
+}
+
+// Validate implements EmailValidator.  Parsing never blocks, so ctx is only checked before parsing begins.
+func (v *Validator) Validate(ctx context.Context, email string) (Result, error) {
+	if err := ctx.Err(); err != nil {
+		return Result{Input: email}, err
+	}
+	return v.Parse(email)
+}
+
+// ValidateChanBuffer is the capacity of the channel returned by ValidateChan
+const ValidateChanBuffer = 16
+
+// ValidateChan validates each address received from in, sending each Result to the returned channel.  Errors are
+// available via Result.Err.  The returned channel is buffered to ValidateChanBuffer results; once full, no further
+// addresses are received from in until the consumer catches up.
+//
+// The returned channel is closed once in is closed or ctx is done, whichever happens first.
+func (v *Validator) ValidateChan(ctx context.Context, in <-chan string) <-chan Result {
+	out := make(chan Result, ValidateChanBuffer)
+	go func() {
+		defer close(out)
+		for {
+			var (
+				email string
+				ok    bool
+			)
+			select {
+			case <-ctx.Done():
+				return
+			case email, ok = <-in:
+				if !ok {
+					return
+				}
+			}
+
+			res, _ := v.Parse(email)
+
+			select {
+			case <-ctx.Done():
+				return
+			case out <- res:
+			}
+		}
+	}()
+	return out
+}
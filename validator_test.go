@@ -0,0 +1,168 @@
+package emailvalidator_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	emailvalidator "github.com/dcarbone/go-email-validator"
+)
+
+func TestValidator_ValidateChan(t *testing.T) {
+	in := make(chan string)
+	go func() {
+		defer close(in)
+		for _, email := range []string{"a@example.com", "abc.example.com", "c@example.com"} {
+			in <- email
+		}
+	}()
+
+	var results []emailvalidator.Result
+	for res := range emailvalidator.NewValidator().ValidateChan(context.Background(), in) {
+		results = append(results, res)
+	}
+
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, saw %d", len(results))
+	}
+	if results[0].Err != nil || results[2].Err != nil {
+		t.Errorf("unexpected errors: %v, %v", results[0].Err, results[2].Err)
+	}
+	if !errors.Is(results[1].Err, emailvalidator.ErrZeroLengthDomain) {
+		t.Errorf("expected ErrZeroLengthDomain, saw %v", results[1].Err)
+	}
+}
+
+func TestValidator_ValidateChanBackpressure(t *testing.T) {
+	var (
+		sent = make(chan int, 1)
+		in   = make(chan string)
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	out := emailvalidator.NewValidator().ValidateChan(ctx, in)
+
+	go func() {
+		n := 0
+		defer func() { sent <- n }()
+		for {
+			select {
+			case in <- "a@example.com":
+				n++
+			case <-time.After(50 * time.Millisecond):
+				return
+			}
+		}
+	}()
+
+	// with nothing consuming, the producer is blocked once the buffer and the in-flight result are full
+	if n := <-sent; n > emailvalidator.ValidateChanBuffer+2 {
+		t.Errorf("expected at most %d addresses to be accepted, saw %d", emailvalidator.ValidateChanBuffer+2, n)
+	}
+
+	cancel()
+	for range out {
+	}
+}
+
+func TestValidator_ParseOverrides(t *testing.T) {
+	v := emailvalidator.NewValidator(emailvalidator.PresetGmailRules)
+
+	if _, err := v.Parse("a@example.com"); !errors.Is(err, emailvalidator.ErrMailboxTooShort) {
+		t.Errorf("expected ErrMailboxTooShort, saw %v", err)
+	}
+
+	relax := func(opt *emailvalidator.ParseOptions) { opt.MailboxMinLength = 0 }
+	if _, err := v.Parse("a@example.com", relax); err != nil {
+		t.Errorf("unexpected error with override: %v", err)
+	}
+
+	// overrides must not leak into subsequent calls
+	if _, err := v.Parse("a@example.com"); !errors.Is(err, emailvalidator.ErrMailboxTooShort) {
+		t.Errorf("expected ErrMailboxTooShort after override, saw %v", err)
+	}
+}
+
+// TestValidator_Concurrent exercises a shared Validator from many goroutines.  It is most useful when run with the
+// race detector, i.e. "go test -race".
+func TestValidator_Concurrent(t *testing.T) {
+	lists := emailvalidator.NewDomainLists()
+	lists.Set("free", []string{"gmail.com"})
+
+	v := emailvalidator.NewValidator(
+		emailvalidator.PresetGmailRules,
+		emailvalidator.WithDomainLists(lists),
+		emailvalidator.WithRoleAccountSeverity(emailvalidator.SeverityWarning, emailvalidator.DefaultRoleAccounts...),
+		emailvalidator.WithLocale("en"),
+	)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				email := fmt.Sprintf("user.%d.%d@gmail.com", i, j)
+
+				if _, err := v.Parse(email); err != nil {
+					t.Errorf("unexpected error: %v", err)
+					return
+				}
+				// per-call overrides which modify maps must not write to the shared base options
+				if _, err := v.Parse("support@gmail.com", emailvalidator.WithRoleAccountSeverity(emailvalidator.SeverityIgnore, "support")); err != nil {
+					t.Errorf("unexpected error: %v", err)
+					return
+				}
+				if _, err := v.Validate(context.Background(), email); err != nil {
+					t.Errorf("unexpected error: %v", err)
+					return
+				}
+				if j%10 == 0 {
+					lists.Set("free", []string{"gmail.com", fmt.Sprintf("example%d.com", j)})
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	// the base options are unchanged by the overrides above
+	res, _ := v.Parse("support@gmail.com")
+	if len(res.Warnings) != 1 {
+		t.Errorf("expected base role severity to be unchanged, saw warnings %v", res.Warnings)
+	}
+}
+
+// TestValidator_OwnsOptions modifies the options a Validator was created from while it is in use.  It is most useful
+// when run with the race detector.
+func TestValidator_OwnsOptions(t *testing.T) {
+	src := emailvalidator.ParseOptions{
+		RoleAccounts:  map[string]emailvalidator.Severity{"support": emailvalidator.SeverityError},
+		ReservedWords: []string{"admin"},
+		Messages:      emailvalidator.Messages{emailvalidator.CodeRoleAccount: "role account"},
+	}
+	v := emailvalidator.NewValidator(emailvalidator.WithOptions(src))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 1000; i++ {
+			src.RoleAccounts["support"] = emailvalidator.SeverityIgnore
+			src.RoleAccounts[fmt.Sprintf("role%d", i)] = emailvalidator.SeverityError
+			src.ReservedWords[0] = fmt.Sprintf("word%d", i)
+			src.Messages[emailvalidator.CodeRoleAccount] = fmt.Sprintf("message %d", i)
+		}
+	}()
+
+	for i := 0; i < 1000; i++ {
+		if _, err := v.Parse("support@example.com"); !errors.Is(err, emailvalidator.ErrRoleAccount) {
+			t.Fatalf("expected ErrRoleAccount, saw %v", err)
+		}
+		if res, _ := v.Parse("admin@example.com"); res.ReservedWord != "admin" {
+			t.Fatalf("expected reserved word %q, saw %q", "admin", res.ReservedWord)
+		}
+	}
+	<-done
+}
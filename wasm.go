@@ -0,0 +1,36 @@
+//go:build js && wasm
+
+package emailvalidator
+
+import (
+	"encoding/json"
+	"syscall/js"
+	"time"
+)
+
+// JSValidateFunc returns a JavaScript function that validates its first argument with opts, returning the Report
+// schema served by Handler as a plain object, so browsers can give instant feedback that matches the server exactly.
+// Optional Go OptFuncs cannot cross into JavaScript, so configure them here:
+//
+//	func main() {
+//		js.Global().Set("validate", emailvalidator.JSValidateFunc(emailvalidator.PresetParanoid))
+//		select {}
+//	}
+//
+// Calling the returned function without a string argument returns null.
+func JSValidateFunc(opts ...OptFunc) js.Func {
+	v := NewValidator(opts...)
+	return js.FuncOf(func(_ js.Value, args []js.Value) any {
+		if len(args) == 0 || args[0].Type() != js.TypeString {
+			return js.Null()
+		}
+
+		start := time.Now()
+		res, err := v.Parse(args[0].String())
+		b, jsonErr := json.Marshal(NewReport(res, err, time.Since(start)))
+		if jsonErr != nil {
+			return js.Null()
+		}
+		return js.Global().Get("JSON").Call("parse", string(b))
+	})
+}